@@ -0,0 +1,186 @@
+package sklib
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	autosuggestFormat   = "http://partners.api.skyscanner.net/apiservices/autosuggest/v1.0/%s/%s/%s?query=%s"
+	skyscannerIdSuffix  = "-sky"
+	maxPlaceSuggestions = 10
+)
+
+type AutosuggestReply struct {
+	Places []AutosuggestPlaceDto
+}
+
+type AutosuggestPlaceDto struct {
+	PlaceId     string
+	PlaceName   string
+	CountryId   string
+	RegionId    string
+	CityId      string
+	CountryName string
+}
+
+// PlaceSuggestion is a candidate place for a free text query,
+// Code can be used as a request origin or destination.
+// Score ranks the candidates, from 1 for an exact match down to 0.
+type PlaceSuggestion struct {
+	Code    string
+	Name    string
+	Type    string
+	Country string
+	Score   float64
+}
+
+type PlaceSuggestions []*PlaceSuggestion
+
+func AutosuggestUrl(localisation Localisation, query string) string {
+	return fmt.Sprintf(autosuggestFormat,
+		localisation.Country,
+		localisation.Currency,
+		localisation.Language,
+		url.QueryEscape(query))
+}
+
+// Autosuggest asks the API for places matching the query,
+// and falls back to the default reference dataset when the API cannot be reached.
+// Error replies are returned, so that a bad key does not pass for local matches.
+func Autosuggest(engine RequestEngine, localisation Localisation, query string) (PlaceSuggestions, error) {
+	if len(strings.TrimSpace(query)) == 0 {
+		return nil, &ValidationError{"Query", "missing"}
+	}
+	if err := ValidateLocalisation(localisation); err != nil {
+		return nil, err
+	}
+	data, err := engine.Get(AutosuggestUrl(localisation, query))
+	if isUnreachable(err) {
+		fmt.Fprintln(Progress, "Autosuggest failed, using reference data:", err)
+		return SuggestPlaces(DefaultReference(), query), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var reply AutosuggestReply
+	if err := ParseJson(data, &reply); err != nil {
+		return nil, err
+	}
+	return ReadAutosuggestReply(&reply), nil
+}
+
+// isUnreachable tells transport errors apart from the API replying with an error.
+func isUnreachable(err error) bool {
+	var transport *url.Error
+	var connection *net.OpError
+	return errors.As(err, &transport) || errors.As(err, &connection)
+}
+
+// ReadAutosuggestReply keeps the API ranking, scores decrease with the position.
+func ReadAutosuggestReply(reply *AutosuggestReply) PlaceSuggestions {
+	results := make(PlaceSuggestions, len(reply.Places))
+	for index, dto := range reply.Places {
+		results[index] = &PlaceSuggestion{
+			Code:    trimSkyscannerId(dto.PlaceId),
+			Name:    dto.PlaceName,
+			Type:    dto.GetType(),
+			Country: trimSkyscannerId(dto.CountryId),
+			Score:   1 - float64(index)/float64(len(reply.Places))}
+	}
+	return results
+}
+
+// GetType tells countries, cities and airports apart by which ids match the place id.
+func (m *AutosuggestPlaceDto) GetType() string {
+	switch m.PlaceId {
+	case m.CountryId:
+		return CountryValue
+	case m.CityId:
+		return CityValue
+	default:
+		return AirportValue
+	}
+}
+
+func trimSkyscannerId(id string) string {
+	return strings.TrimSuffix(id, skyscannerIdSuffix)
+}
+
+// SuggestPlaces matches the query against the codes and names of the reference places, ignoring case.
+func SuggestPlaces(reference *ReferenceDB, query string) PlaceSuggestions {
+	query = strings.ToLower(strings.TrimSpace(query))
+	results := make(PlaceSuggestions, 0)
+	if len(query) == 0 {
+		return results
+	}
+	for _, place := range reference.GetPlaces() {
+		score := scorePlace(place, query)
+		if score > 0 {
+			results = append(results, &PlaceSuggestion{
+				Code:    place.Code,
+				Name:    place.Name,
+				Type:    place.Type,
+				Country: place.Country,
+				Score:   score})
+		}
+	}
+	sort.Stable(results)
+	if len(results) > maxPlaceSuggestions {
+		results = results[:maxPlaceSuggestions]
+	}
+	return results
+}
+
+func scorePlace(place *ReferencePlace, query string) float64 {
+	name := strings.ToLower(place.Name)
+	switch {
+	case strings.ToLower(place.Code) == query:
+		return 1
+	case name == query:
+		return 0.95
+	case strings.HasPrefix(name, query):
+		return 0.9
+	case strings.Contains(name, " "+query):
+		return 0.8
+	case strings.Contains(name, query):
+		return 0.7
+	}
+	// Typos are only worth matching on longer queries, short ones match too much.
+	if len(query) < 4 {
+		return 0
+	}
+	distance := editDistance(query, name)
+	if len(name) > len(query) {
+		distance = minInt(distance, editDistance(query, name[:len(query)]))
+	}
+	if distance > maxSuggestionCost {
+		return 0
+	}
+	return 0.6 - 0.1*float64(distance)
+}
+
+// Cities come before their airports on equal scores.
+func (slice PlaceSuggestions) Less(i, j int) bool {
+	left := slice[i]
+	right := slice[j]
+	if left.Score != right.Score {
+		return left.Score > right.Score
+	}
+	if left.Type != right.Type {
+		return ComparePlaceType(left.Type, right.Type)
+	}
+	return left.Code < right.Code
+}
+
+func (slice PlaceSuggestions) Len() int {
+	return len(slice)
+}
+
+func (slice PlaceSuggestions) Swap(i, j int) {
+	slice[i], slice[j] = slice[j], slice[i]
+}
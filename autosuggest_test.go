@@ -0,0 +1,82 @@
+package sklib
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/url"
+	"testing"
+)
+
+const AutosuggestLocation = TestDataBase + "autosuggest.json"
+
+func TestAutosuggest(t *testing.T) {
+	localisation := Localisation{"GB", "GBP", "en-GB"}
+	engine := NewTestEngine(map[string]string{
+		AutosuggestUrl(localisation, "barce lona"): AutosuggestLocation})
+	assert.Equal(t,
+		"http://partners.api.skyscanner.net/apiservices/autosuggest/v1.0/GB/GBP/en-GB?query=barce+lona",
+		AutosuggestUrl(localisation, "barce lona"))
+
+	api := &EngineSearchAPI{engine: engine}
+	suggestions, err := api.Autosuggest(localisation, "barce lona")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(suggestions))
+	assert.Equal(t, PlaceSuggestion{"BCN", "Barcelona", AirportValue, "ES", 1}, *suggestions[0])
+	assert.Equal(t, CityValue, suggestions[1].Type)
+	assert.Equal(t, "BCNA", suggestions[1].Code)
+	assert.Equal(t, CountryValue, suggestions[3].Type)
+	assert.True(t, suggestions[1].Score > suggestions[2].Score)
+
+	_, err = api.Autosuggest(localisation, " ")
+	assert.Equal(t, "Query", err.(*ValidationError).Field)
+}
+
+// OfflineEngine fails every request as if the network was down.
+type OfflineEngine struct {
+	Calls int
+}
+
+func (m *OfflineEngine) Get(address string) ([]byte, error) {
+	m.Calls++
+	return nil, &url.Error{Op: "Get", URL: address, Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+}
+
+func (m *OfflineEngine) PostAndPoll(address string, form url.Values) ([]byte, error) {
+	return m.Get(address)
+}
+
+func TestAutosuggestFallback(t *testing.T) {
+	engine := &OfflineEngine{}
+	suggestions, err := Autosuggest(engine, Localisation{"GB", "GBP", "en-GB"}, "Barcelona")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, engine.Calls)
+	assert.Equal(t, "BCN", suggestions[0].Code)
+	assert.Equal(t, CityValue, suggestions[0].Type)
+	assert.Equal(t, AirportValue, suggestions[1].Type)
+}
+
+func TestAutosuggestErrors(t *testing.T) {
+	localisation := Localisation{"GB", "GBP", "en-GB"}
+	engine := NewTestEngine(map[string]string{
+		AutosuggestUrl(localisation, "Barcelona"): CurrenciesLocation})
+	_, err := Autosuggest(engine, localisation, "Barcelona")
+	assert.NotNil(t, err)
+
+	_, err = Autosuggest(engine, localisation, "Vienna")
+	assert.NotNil(t, err)
+}
+
+func TestSuggestPlaces(t *testing.T) {
+	reference := DefaultReference()
+	assert.Equal(t, "LHR", SuggestPlaces(reference, "lhr")[0].Code)
+	assert.Equal(t, "BCN", SuggestPlaces(reference, "Barcelnoa")[0].Code)
+	assert.Equal(t, "VIE", SuggestPlaces(reference, "vien")[0].Code)
+	assert.Equal(t, 0, len(SuggestPlaces(reference, "zzzzzzzz")))
+	assert.True(t, len(SuggestPlaces(reference, "a")) <= maxPlaceSuggestions)
+}
+
+func TestFormatKey(t *testing.T) {
+	assert.Equal(t, "http://host/path?apiKey=key", formatKey("http://host/path", "key"))
+	assert.Equal(t, "http://host/path?query=lon&apiKey=key", formatKey("http://host/path?query=lon", "key"))
+}
@@ -0,0 +1,284 @@
+package sklib
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const partialMonthFormat = "2006-01"
+
+type BrowseQuotesReply struct {
+	XMLName    xml.Name      `xml:"BrowseQuotesResponseApiDto"`
+	Currencies []CurrencyDto `xml:">CurrencyDto"`
+	Quotes     []QuoteDto    `xml:">QuoteDto"`
+	Places     []PlaceDto    `xml:">PlaceDto"`
+	Carriers   []CarriersDto `xml:">CarriersDto"`
+}
+
+type BrowseDatesReply struct {
+	XMLName    xml.Name `xml:"BrowseDatesResponseApiDto"`
+	Dates      DatesDto
+	Currencies []CurrencyDto `xml:">CurrencyDto"`
+	Quotes     []QuoteDto    `xml:">QuoteDto"`
+	Places     []PlaceDto    `xml:">PlaceDto"`
+	Carriers   []CarriersDto `xml:">CarriersDto"`
+}
+
+type DatesDto struct {
+	OutboundDates []DateDto `xml:"OutboundDates>DateDto"`
+	InboundDates  []DateDto `xml:"InboundDates>DateDto"`
+}
+
+type DateDto struct {
+	PartialDate   string
+	QuoteIds      []int `xml:">int"`
+	Price         Decimal
+	QuoteDateTime string
+}
+
+// BrowseGridReply is only available in JSON.
+// The first row of Dates holds the inbound dates and the first column the outbound dates,
+// the other cells the cheapest price of the pair, or null.
+type BrowseGridReply struct {
+	Dates      [][]*GridCellDto
+	Currencies []CurrencyDto
+	Places     []PlaceDto
+	Carriers   []CarriersDto
+}
+
+type GridCellDto struct {
+	DateString    string
+	MinPrice      Decimal
+	QuoteDateTime string
+}
+
+// DatePrice is the cheapest quote for a day, or a month for partial dates.
+type DatePrice struct {
+	Date     time.Time
+	Price    Money
+	QuoteIds []int
+}
+
+type DatePrices []*DatePrice
+
+type PriceCalendar struct {
+	Outbound DatePrices
+	Inbound  DatePrices
+}
+
+// PriceGrid holds the cheapest price of each outbound and inbound date pair,
+// Prices[i][j] is nil when there is no quote for OutboundDates[i] and InboundDates[j].
+type PriceGrid struct {
+	OutboundDates []time.Time
+	InboundDates  []time.Time
+	Prices        [][]*Money
+}
+
+func RunBrowseQuotes(engine RequestEngine, request BrowseRoutesRequest) (*BrowseQuotesReply, error) {
+	var reply BrowseQuotesReply
+	if err := runBrowseRequest(engine, request.ServiceUrl(browseQuotesPath), request, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func RunBrowseDates(engine RequestEngine, request BrowseRoutesRequest) (*BrowseDatesReply, error) {
+	var reply BrowseDatesReply
+	if err := runBrowseRequest(engine, request.ServiceUrl(browseDatesPath), request, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func RunBrowseGrid(engine RequestEngine, request BrowseRoutesRequest) (*BrowseGridReply, error) {
+	var reply BrowseGridReply
+	if err := runBrowseRequest(engine, request.ServiceUrl(browseGridPath), request, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func runBrowseRequest(engine RequestEngine, url string, request BrowseRoutesRequest, reply interface{}) error {
+	if err := ValidateLocalisation(request.Localisation); err != nil {
+		return err
+	}
+	data, err := engine.Get(url)
+	if err != nil {
+		return err
+	}
+	return ParseJson(data, reply)
+}
+
+// BrowseQuotes lists every cached quote of the route, cheapest first.
+func BrowseQuotes(engine RequestEngine, request BrowseRoutesRequest) (FullQuotes, error) {
+	reply, err := RunBrowseQuotes(engine, request)
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetFullQuotes(), nil
+}
+
+// BrowseDates gets the cheapest price per departure and per return date.
+func BrowseDates(engine RequestEngine, request BrowseRoutesRequest) (*PriceCalendar, error) {
+	reply, err := RunBrowseDates(engine, request)
+	if err != nil {
+		return nil, err
+	}
+	return ReadBrowseDatesReply(reply)
+}
+
+func BrowseGrid(engine RequestEngine, request BrowseRoutesRequest) (*PriceGrid, error) {
+	reply, err := RunBrowseGrid(engine, request)
+	if err != nil {
+		return nil, err
+	}
+	return ReadBrowseGridReply(reply)
+}
+
+func (m *BrowseQuotesReply) asRoutesReply() *BrowseRoutesReply {
+	return &BrowseRoutesReply{
+		Currencies: m.Currencies,
+		Quotes:     m.Quotes,
+		Places:     m.Places,
+		Carriers:   m.Carriers}
+}
+
+func (m *BrowseQuotesReply) GetFullQuotes() FullQuotes {
+	routes := m.asRoutesReply()
+	places := routes.GetPlacesById()
+	currency := routes.GetCurrency()
+	results := make(FullQuotes, 0, len(m.Quotes))
+	for _, quote := range m.Quotes {
+		destination := places[quote.OutboundLeg.DestinationId]
+		results = append(results, FullQuote{quote, destination, currency, nil})
+	}
+	sort.Stable(results)
+	return results
+}
+
+func ReadBrowseDatesReply(reply *BrowseDatesReply) (*PriceCalendar, error) {
+	currency := (&BrowseRoutesReply{Currencies: reply.Currencies}).GetCurrency()
+	outbound, err := readDatePrices(reply.Dates.OutboundDates, currency)
+	if err != nil {
+		return nil, err
+	}
+	inbound, err := readDatePrices(reply.Dates.InboundDates, currency)
+	if err != nil {
+		return nil, err
+	}
+	return &PriceCalendar{outbound, inbound}, nil
+}
+
+func readDatePrices(dtos []DateDto, currency *Currency) (DatePrices, error) {
+	results := make(DatePrices, len(dtos))
+	for index, dto := range dtos {
+		date, err := ParsePartialDate(dto.PartialDate)
+		if err != nil {
+			return nil, err
+		}
+		results[index] = &DatePrice{date, Money{dto.Price, currency}, dto.QuoteIds}
+	}
+	sort.Sort(results)
+	return results, nil
+}
+
+// ParsePartialDate reads the days and months browse services reply with.
+func ParsePartialDate(input string) (time.Time, error) {
+	if date, err := time.Parse(DateFormatForm, input); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse(partialMonthFormat, input)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid partial date %s", input)
+	}
+	return date, nil
+}
+
+func ReadBrowseGridReply(reply *BrowseGridReply) (*PriceGrid, error) {
+	if len(reply.Dates) == 0 {
+		return nil, fmt.Errorf("Empty browse grid")
+	}
+	currency := (&BrowseRoutesReply{Currencies: reply.Currencies}).GetCurrency()
+	header := reply.Dates[0]
+	if len(header) == 0 {
+		return nil, fmt.Errorf("Missing browse grid header")
+	}
+	result := &PriceGrid{
+		OutboundDates: make([]time.Time, 0, len(reply.Dates)-1),
+		InboundDates:  make([]time.Time, 0, len(header)-1),
+		Prices:        make([][]*Money, 0, len(reply.Dates)-1)}
+	for _, cell := range header[1:] {
+		date, err := readGridDate(cell)
+		if err != nil {
+			return nil, err
+		}
+		result.InboundDates = append(result.InboundDates, date)
+	}
+	for _, row := range reply.Dates[1:] {
+		if len(row) != len(header) {
+			return nil, fmt.Errorf("Browse grid row has %d cells, expected %d", len(row), len(header))
+		}
+		date, err := readGridDate(row[0])
+		if err != nil {
+			return nil, err
+		}
+		result.OutboundDates = append(result.OutboundDates, date)
+		prices := make([]*Money, len(row)-1)
+		for index, cell := range row[1:] {
+			if cell != nil {
+				prices[index] = &Money{cell.MinPrice, currency}
+			}
+		}
+		result.Prices = append(result.Prices, prices)
+	}
+	return result, nil
+}
+
+func readGridDate(cell *GridCellDto) (time.Time, error) {
+	if cell == nil {
+		return time.Time{}, fmt.Errorf("Missing browse grid date")
+	}
+	return ParsePartialDate(cell.DateString)
+}
+
+// Get is nil when either date is not in the grid, or has no quote.
+func (m *PriceGrid) Get(outbound time.Time, inbound time.Time) *Money {
+	for i, outboundDate := range m.OutboundDates {
+		if !outboundDate.Equal(outbound) {
+			continue
+		}
+		for j, inboundDate := range m.InboundDates {
+			if inboundDate.Equal(inbound) {
+				return m.Prices[i][j]
+			}
+		}
+	}
+	return nil
+}
+
+// GetCheapest returns the indices of the cheapest cell, or false for an empty grid.
+func (m *PriceGrid) GetCheapest() (int, int, bool) {
+	bestI, bestJ, found := 0, 0, false
+	for i, row := range m.Prices {
+		for j, price := range row {
+			if price != nil && (!found || price.Amount < m.Prices[bestI][bestJ].Amount) {
+				bestI, bestJ, found = i, j, true
+			}
+		}
+	}
+	return bestI, bestJ, found
+}
+
+func (slice DatePrices) Len() int {
+	return len(slice)
+}
+
+func (slice DatePrices) Less(i, j int) bool {
+	return slice[i].Date.Before(slice[j].Date)
+}
+
+func (slice DatePrices) Swap(i, j int) {
+	slice[i], slice[j] = slice[j], slice[i]
+}
@@ -0,0 +1,96 @@
+package sklib
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const (
+	BrowseQuotesLocation = TestDataBase + "browsequotes.json"
+	BrowseDatesLocation  = TestDataBase + "browsedates.json"
+	BrowseGridLocation   = TestDataBase + "browsegrid.json"
+)
+
+func GetTestBrowseRequest() BrowseRoutesRequest {
+	return BrowseRoutesRequest{Localisation{"GB", "GBP", "en-GB"}, "LGW", "BCN", "20160819", "20160821"}
+}
+
+func GetTestBrowseEngine() *TestEngine {
+	request := GetTestBrowseRequest()
+	return NewTestEngine(map[string]string{
+		request.ServiceUrl(browseQuotesPath): BrowseQuotesLocation,
+		request.ServiceUrl(browseDatesPath):  BrowseDatesLocation,
+		request.ServiceUrl(browseGridPath):   BrowseGridLocation})
+}
+
+func TestBrowseServiceUrl(t *testing.T) {
+	request := GetTestBrowseRequest()
+	assert.Equal(t,
+		"http://partners.api.skyscanner.net/apiservices/browsedates/v1.0/GB/GBP/en-GB/LGW/BCN/20160819/20160821",
+		request.ServiceUrl(browseDatesPath))
+	request.DepartureDate = "2016-08"
+	request.ReturnDate = ""
+	assert.Equal(t,
+		"http://partners.api.skyscanner.net/apiservices/browsegrid/v1.0/GB/GBP/en-GB/LGW/BCN/2016-08",
+		request.ServiceUrl(browseGridPath))
+}
+
+func TestBrowseQuotes(t *testing.T) {
+	quotes, err := BrowseQuotes(GetTestBrowseEngine(), GetTestBrowseRequest())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(quotes))
+	assert.Equal(t, 2, quotes[0].Quote.QuoteId)
+	assert.Equal(t, "Barcelona £64.00", quotes[0].Display())
+	assert.Equal(t, ParseDecimalOP("86.5"), quotes[1].Quote.MinPrice)
+}
+
+func TestBrowseDates(t *testing.T) {
+	calendar, err := BrowseDates(GetTestBrowseEngine(), GetTestBrowseRequest())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(calendar.Outbound))
+	assert.Equal(t, time.Date(2016, 8, 19, 0, 0, 0, 0, time.UTC), calendar.Outbound[0].Date)
+	assert.Equal(t, "£64.00", calendar.Outbound[0].Price.String())
+	assert.Equal(t, "£71.20", calendar.Outbound[1].Price.String())
+	assert.Equal(t, []int{1, 2}, calendar.Inbound[0].QuoteIds)
+}
+
+func TestBrowseDatesXml(t *testing.T) {
+	input := `<BrowseDatesResponseApiDto><Dates>
+		<OutboundDates><DateDto><PartialDate>2016-08-19</PartialDate><Price>42</Price></DateDto></OutboundDates>
+		<InboundDates><DateDto><PartialDate>2016-08-21</PartialDate><Price>38.5</Price></DateDto></InboundDates>
+	</Dates></BrowseDatesResponseApiDto>`
+	var reply BrowseDatesReply
+	assert.Nil(t, xml.Unmarshal([]byte(input), &reply))
+	assert.Equal(t, "2016-08-19", reply.Dates.OutboundDates[0].PartialDate)
+	assert.Equal(t, "2016-08-21", reply.Dates.InboundDates[0].PartialDate)
+	assert.Equal(t, ParseDecimalOP("38.5"), reply.Dates.InboundDates[0].Price)
+}
+
+func TestBrowseGrid(t *testing.T) {
+	grid, err := BrowseGrid(GetTestBrowseEngine(), GetTestBrowseRequest())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(grid.OutboundDates))
+	assert.Equal(t, 2, len(grid.InboundDates))
+	assert.Nil(t, grid.Prices[1][1])
+	day := func(d int) time.Time { return time.Date(2016, 8, d, 0, 0, 0, 0, time.UTC) }
+	assert.Equal(t, ParseDecimalOP("92.3"), grid.Get(day(19), day(22)).Amount)
+	assert.Nil(t, grid.Get(day(20), day(22)))
+	assert.Nil(t, grid.Get(day(25), day(22)))
+	i, j, found := grid.GetCheapest()
+	assert.True(t, found)
+	assert.Equal(t, 0, i)
+	assert.Equal(t, 0, j)
+
+	_, err = ReadBrowseGridReply(&BrowseGridReply{})
+	assert.NotNil(t, err)
+}
+
+func TestParsePartialDate(t *testing.T) {
+	month, err := ParsePartialDate("2016-08")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2016, 8, 1, 0, 0, 0, 0, time.UTC), month)
+	_, err = ParsePartialDate("20160801")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,108 @@
+package sklib
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CountryBudget holds the towns of a country within budget, cheapest first.
+type CountryBudget struct {
+	Country PlaceDto
+	Quotes  FullQuotes
+}
+
+type CountryBudgets []*CountryBudget
+
+// ExploreBudget runs the Browse country and town rounds, keeping destinations up to maxPrice.
+// Countries whose anywhere quote is over budget are not searched at all,
+// neither are towns whose country round quote is over budget.
+func ExploreBudget(engine RequestEngine, arguments BrowseRoutesRequest, maxPrice Decimal) (CountryBudgets, error) {
+	if err := ValidateLocalisation(arguments.Localisation); err != nil {
+		return nil, err
+	}
+	if maxPrice <= 0 {
+		return nil, &ValidationError{"MaxPrice", "must be positive"}
+	}
+	request := NewBrowseRouteRequest(arguments.Localisation, arguments.Origin, arguments.DepartureDate, arguments.ReturnDate)
+	fmt.Fprintln(Progress, "Searching countries...")
+	reply, err := RunRequest(engine, request)
+	if err != nil {
+		return nil, err
+	}
+
+	countryPrices := make(map[string]Decimal)
+	for _, quote := range reply.GetBestQuotesFor(request.IsReturn()) {
+		countryPrices[quote.Destination.SkyscannerCode] = quote.Quote.MinPrice
+	}
+	countries := make([]PlaceDto, 0)
+	for _, country := range reply.GetCountries() {
+		if price, exists := countryPrices[country.SkyscannerCode]; exists && price > maxPrice {
+			continue
+		}
+		countries = append(countries, country)
+	}
+	fmt.Fprintf(Progress, "Skipping %d countries over budget\n", len(reply.GetCountries())-len(countries))
+
+	countryResults, err := browseDestinations(request, countries, engine)
+	if err != nil {
+		return nil, err
+	}
+	townCountries := make(map[string]PlaceDto)
+	towns := make([]PlaceDto, 0)
+	for index, results := range countryResults {
+		for _, quote := range results.Data.GetBestQuotesFor(request.IsReturn()) {
+			code := quote.Destination.SkyscannerCode
+			if _, exists := townCountries[code]; exists || quote.Quote.MinPrice > maxPrice {
+				continue
+			}
+			townCountries[code] = countries[index]
+			towns = append(towns, quote.Destination)
+		}
+	}
+
+	fmt.Fprintln(Progress, "Searching towns...")
+	townResults, err := browseDestinations(request, towns, engine)
+	if err != nil {
+		return nil, err
+	}
+	budgets := make(map[string]*CountryBudget)
+	for index, results := range townResults {
+		town := towns[index]
+		for _, quote := range results.Data.GetBestQuotesFor(request.IsReturn()) {
+			if quote.Destination.SkyscannerCode != town.SkyscannerCode || quote.Quote.MinPrice > maxPrice {
+				continue
+			}
+			country := townCountries[town.SkyscannerCode]
+			budget, exists := budgets[country.SkyscannerCode]
+			if !exists {
+				budget = &CountryBudget{Country: country, Quotes: make(FullQuotes, 0)}
+				budgets[country.SkyscannerCode] = budget
+			}
+			budget.Quotes = append(budget.Quotes, quote)
+		}
+	}
+
+	results := make(CountryBudgets, 0, len(budgets))
+	for _, budget := range budgets {
+		sort.Sort(budget.Quotes)
+		results = append(results, budget)
+	}
+	sort.Sort(results)
+	return results, nil
+}
+
+func (m *CountryBudget) GetCheapest() Decimal {
+	return m.Quotes[0].Quote.MinPrice
+}
+
+func (slice CountryBudgets) Len() int {
+	return len(slice)
+}
+
+func (slice CountryBudgets) Less(i, j int) bool {
+	return slice[i].GetCheapest() < slice[j].GetCheapest()
+}
+
+func (slice CountryBudgets) Swap(i, j int) {
+	slice[i], slice[j] = slice[j], slice[i]
+}
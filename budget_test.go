@@ -0,0 +1,38 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const BudgetAnywhereLocation = TestDataBase + "budget_anywhere.json"
+
+func TestExploreBudget(t *testing.T) {
+	request := NewBrowseRouteRequest(Localisation{"GB", "GBP", "en-GB"}, "LGW", "20160819", "20160821")
+	subRequest := func(destination string) string {
+		return BrowseRoutesRequest{request.Localisation, request.Origin, destination, request.DepartureDate, request.ReturnDate}.Url()
+	}
+	engine := NewTestEngine(map[string]string{
+		request.Url():     BudgetAnywhereLocation,
+		subRequest("ES"):  WeekendLocation,
+		subRequest("BCN"): WeekendLocation,
+		subRequest("MAD"): WeekendLocation})
+
+	results, err := ExploreBudget(engine, request, NewDecimal(60))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, "Spain", results[0].Country.Name)
+	assert.Equal(t, 1, len(results[0].Quotes))
+	assert.Equal(t, "MAD", results[0].Quotes[0].Destination.SkyscannerCode)
+	assert.Equal(t, 0, engine.Calls[subRequest("IT")])
+	assert.Equal(t, 0, engine.Calls[subRequest("BCN")])
+
+	results, err = ExploreBudget(engine, request, NewDecimal(100))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(results[0].Quotes))
+	assert.Equal(t, ParseDecimalOP("49.99"), results[0].GetCheapest())
+	assert.Equal(t, 0, engine.Calls[subRequest("IT")])
+
+	_, err = ExploreBudget(engine, request, 0)
+	assert.Equal(t, "MaxPrice", err.(*ValidationError).Field)
+}
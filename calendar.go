@@ -0,0 +1,339 @@
+package sklib
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	anytime             = "anytime"
+	anytimeDays         = 90
+	dateRangeSeparator  = ":"
+	maxCalendarRequests = 100
+	hoursPerDay         = 24
+	calendarCacheTTL    = time.Hour
+)
+
+// CalendarRequest searches every departure and return date pair allowed by the constraints.
+// DepartureDate and ReturnDate are a day ("20160819"), a month ("2016-08"),
+// a range of days ("20160801:20160815") or "anytime", meaning the next 90 days from Today.
+// An empty ReturnDate searches one-way trips.
+// MinNights and MaxNights bound the stay, 0 meaning no bound.
+// DepartureWeekdays and ReturnWeekdays, when set, restrict the days of the week.
+type CalendarRequest struct {
+	Localisation      Localisation
+	Origin            string
+	Destination       string
+	DepartureDate     string
+	ReturnDate        string
+	MinNights         int
+	MaxNights         int
+	DepartureWeekdays []time.Weekday
+	ReturnWeekdays    []time.Weekday
+	Today             time.Time
+}
+
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// DatePair has a zero Return for one-way trips.
+type DatePair struct {
+	Departure time.Time
+	Return    time.Time
+}
+
+// CalendarResult is the price matrix of a destination,
+// one-way searches have a single, zero, inbound date.
+type CalendarResult struct {
+	Destination PlaceDto
+	Grid        *PriceGrid
+}
+
+type CalendarResults []*CalendarResult
+
+// CalendarSearcher shares its cache between the searches it runs,
+// as calendars often overlap. Cached prices are kept for an hour.
+type CalendarSearcher struct {
+	engine RequestEngine
+}
+
+func NewCalendarSearcher(engine RequestEngine) *CalendarSearcher {
+	cache := &ExpiringStore{Store: NewMemoryStore(), TTL: calendarCacheTTL}
+	return &CalendarSearcher{&CachedEngine{Engine: engine, Cache: cache}}
+}
+
+// ParseDateRange reads the date formats of a CalendarRequest.
+func ParseDateRange(input string, today time.Time) (DateRange, error) {
+	if input == anytime {
+		from := truncateDay(today)
+		return DateRange{from, from.AddDate(0, 0, anytimeDays-1)}, nil
+	}
+	if parts := strings.Split(input, dateRangeSeparator); len(parts) == 2 {
+		from, err := ParseUrlDate(parts[0])
+		if err != nil {
+			return DateRange{}, err
+		}
+		to, err := ParseUrlDate(parts[1])
+		if err != nil {
+			return DateRange{}, err
+		}
+		if to.Before(from) {
+			return DateRange{}, fmt.Errorf("Date range %s ends before it starts", input)
+		}
+		return DateRange{from, to}, nil
+	}
+	if month, err := time.Parse(partialMonthFormat, input); err == nil {
+		return DateRange{month, month.AddDate(0, 1, -1)}, nil
+	}
+	day, err := ParseUrlDate(input)
+	if err != nil {
+		return DateRange{}, err
+	}
+	return DateRange{day, day}, nil
+}
+
+func truncateDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Days lists the days of the range, both ends included.
+func (m DateRange) Days() []time.Time {
+	results := make([]time.Time, 0)
+	for day := m.From; !day.After(m.To); day = day.AddDate(0, 0, 1) {
+		results = append(results, day)
+	}
+	return results
+}
+
+func (m *CalendarRequest) IsReturn() bool {
+	return len(m.ReturnDate) != 0
+}
+
+func (m *CalendarRequest) GetToday() time.Time {
+	if m.Today.IsZero() {
+		return time.Now()
+	}
+	return m.Today
+}
+
+func (m *CalendarRequest) GetDestination() string {
+	if len(m.Destination) == 0 {
+		return anywhere
+	}
+	return m.Destination
+}
+
+func (m *CalendarRequest) Validate() error {
+	if len(m.Origin) == 0 {
+		return &ValidationError{"Origin", "missing"}
+	}
+	if m.MinNights < 0 {
+		return &ValidationError{"MinNights", "cannot be negative"}
+	}
+	if m.MaxNights < 0 {
+		return &ValidationError{"MaxNights", "cannot be negative"}
+	}
+	if m.MaxNights != 0 && m.MaxNights < m.MinNights {
+		return &ValidationError{"MaxNights", fmt.Sprintf("%d is less than MinNights %d", m.MaxNights, m.MinNights)}
+	}
+	if _, err := ParseDateRange(m.DepartureDate, m.GetToday()); err != nil {
+		return &ValidationError{"DepartureDate", err.Error()}
+	}
+	if m.IsReturn() {
+		if _, err := ParseDateRange(m.ReturnDate, m.GetToday()); err != nil {
+			return &ValidationError{"ReturnDate", err.Error()}
+		}
+	}
+	return nil
+}
+
+// DatePairs expands the request into the dates to search, in chronological order.
+func (m *CalendarRequest) DatePairs() ([]DatePair, error) {
+	departures, err := ParseDateRange(m.DepartureDate, m.GetToday())
+	if err != nil {
+		return nil, err
+	}
+	results := make([]DatePair, 0)
+	if !m.IsReturn() {
+		for _, departure := range departures.Days() {
+			if hasWeekday(m.DepartureWeekdays, departure) {
+				results = append(results, DatePair{Departure: departure})
+			}
+		}
+		return results, nil
+	}
+	returns, err := ParseDateRange(m.ReturnDate, m.GetToday())
+	if err != nil {
+		return nil, err
+	}
+	for _, departure := range departures.Days() {
+		if !hasWeekday(m.DepartureWeekdays, departure) {
+			continue
+		}
+		for _, inbound := range returns.Days() {
+			if hasWeekday(m.ReturnWeekdays, inbound) && m.allowsStay(departure, inbound) {
+				results = append(results, DatePair{departure, inbound})
+			}
+		}
+	}
+	return results, nil
+}
+
+func (m *CalendarRequest) allowsStay(departure time.Time, inbound time.Time) bool {
+	nights := int(inbound.Sub(departure).Hours() / hoursPerDay)
+	if nights < 0 || nights < m.MinNights {
+		return false
+	}
+	return m.MaxNights == 0 || nights <= m.MaxNights
+}
+
+func hasWeekday(weekdays []time.Weekday, date time.Time) bool {
+	if len(weekdays) == 0 {
+		return true
+	}
+	for _, weekday := range weekdays {
+		if date.Weekday() == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func (m DatePair) BrowseRequest(request *CalendarRequest) BrowseRoutesRequest {
+	returnDate := ""
+	if !m.Return.IsZero() {
+		returnDate = m.Return.Format(DateFormatUrl)
+	}
+	return BrowseRoutesRequest{
+		request.Localisation,
+		request.Origin,
+		request.GetDestination(),
+		m.Departure.Format(DateFormatUrl),
+		returnDate}
+}
+
+// Search runs a browse routes request per date pair, and collects the best quote
+// of each destination into its price matrix, cheapest destination first.
+func (m *CalendarSearcher) Search(request CalendarRequest) (CalendarResults, error) {
+	if err := ValidateLocalisation(request.Localisation); err != nil {
+		return nil, err
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	pairs, err := request.DatePairs()
+	if err != nil {
+		return nil, err
+	}
+	if len(pairs) > maxCalendarRequests {
+		return nil, &ValidationError{"DepartureDate", fmt.Sprintf("%d date pairs to search, at most %d allowed", len(pairs), maxCalendarRequests)}
+	}
+	builder := newCalendarBuilder(pairs)
+	for index, pair := range pairs {
+		fmt.Fprintf(Progress, "\rSearching dates %d/%d", index, len(pairs))
+		reply, err := RunRequest(m.engine, pair.BrowseRequest(&request))
+		if err != nil {
+			return nil, err
+		}
+		for _, quote := range reply.GetBestQuotesFor(request.IsReturn()) {
+			builder.add(pair, quote)
+		}
+	}
+	fmt.Fprintf(Progress, "\n")
+	return builder.results(), nil
+}
+
+type calendarBuilder struct {
+	outbound     []time.Time
+	inbound      []time.Time
+	destinations map[string]*CalendarResult
+}
+
+func newCalendarBuilder(pairs []DatePair) *calendarBuilder {
+	outbound := make(map[time.Time]bool)
+	inbound := make(map[time.Time]bool)
+	for _, pair := range pairs {
+		outbound[pair.Departure] = true
+		inbound[pair.Return] = true
+	}
+	return &calendarBuilder{
+		outbound:     sortedDates(outbound),
+		inbound:      sortedDates(inbound),
+		destinations: make(map[string]*CalendarResult)}
+}
+
+func sortedDates(dates map[time.Time]bool) []time.Time {
+	results := make([]time.Time, 0, len(dates))
+	for date := range dates {
+		results = append(results, date)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Before(results[j]) })
+	return results
+}
+
+func (m *calendarBuilder) add(pair DatePair, quote FullQuote) {
+	code := quote.Destination.SkyscannerCode
+	result, exists := m.destinations[code]
+	if !exists {
+		prices := make([][]*Money, len(m.outbound))
+		for index := range prices {
+			prices[index] = make([]*Money, len(m.inbound))
+		}
+		result = &CalendarResult{
+			Destination: quote.Destination,
+			Grid:        &PriceGrid{m.outbound, m.inbound, prices}}
+		m.destinations[code] = result
+	}
+	i := indexOfDate(m.outbound, pair.Departure)
+	j := indexOfDate(m.inbound, pair.Return)
+	price := quote.GetMoney()
+	if current := result.Grid.Prices[i][j]; current == nil || price.Amount < current.Amount {
+		result.Grid.Prices[i][j] = &price
+	}
+}
+
+func indexOfDate(dates []time.Time, date time.Time) int {
+	return sort.Search(len(dates), func(index int) bool { return !dates[index].Before(date) })
+}
+
+func (m *calendarBuilder) results() CalendarResults {
+	results := make(CalendarResults, 0, len(m.destinations))
+	for _, result := range m.destinations {
+		results = append(results, result)
+	}
+	sort.Sort(results)
+	return results
+}
+
+// GetCheapest is nil for an empty grid.
+func (m *CalendarResult) GetCheapest() *Money {
+	i, j, found := m.Grid.GetCheapest()
+	if !found {
+		return nil
+	}
+	return m.Grid.Prices[i][j]
+}
+
+func (slice CalendarResults) Len() int {
+	return len(slice)
+}
+
+func (slice CalendarResults) Less(i, j int) bool {
+	left := slice[i].GetCheapest()
+	right := slice[j].GetCheapest()
+	if left == nil || right == nil {
+		return right == nil && left != nil
+	}
+	if left.Amount == right.Amount {
+		return slice[i].Destination.SkyscannerCode < slice[j].Destination.SkyscannerCode
+	}
+	return left.Amount < right.Amount
+}
+
+func (slice CalendarResults) Swap(i, j int) {
+	slice[i], slice[j] = slice[j], slice[i]
+}
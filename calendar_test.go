@@ -0,0 +1,108 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const CalendarRoutesLocation = TestDataBase + "calendar_routes.json"
+
+func utcDay(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseDateRange(t *testing.T) {
+	today := time.Date(2016, 8, 10, 15, 30, 0, 0, time.UTC)
+	month, err := ParseDateRange("2016-02", today)
+	assert.Nil(t, err)
+	assert.Equal(t, DateRange{utcDay(2016, 2, 1), utcDay(2016, 2, 29)}, month)
+	assert.Equal(t, 29, len(month.Days()))
+
+	single, err := ParseDateRange("20160819", today)
+	assert.Nil(t, err)
+	assert.Equal(t, DateRange{utcDay(2016, 8, 19), utcDay(2016, 8, 19)}, single)
+
+	anyDate, err := ParseDateRange(anytime, today)
+	assert.Nil(t, err)
+	assert.Equal(t, utcDay(2016, 8, 10), anyDate.From)
+	assert.Equal(t, anytimeDays, len(anyDate.Days()))
+
+	_, err = ParseDateRange("20160820:20160819", today)
+	assert.NotNil(t, err)
+	_, err = ParseDateRange("2016-08-19", today)
+	assert.NotNil(t, err)
+}
+
+func TestCalendarDatePairs(t *testing.T) {
+	request := CalendarRequest{
+		Origin:            "LON",
+		DepartureDate:     "2016-03",
+		ReturnDate:        "2016-03",
+		MinNights:         2,
+		MaxNights:         3,
+		DepartureWeekdays: []time.Weekday{time.Friday},
+		ReturnWeekdays:    []time.Weekday{time.Sunday, time.Monday}}
+	pairs, err := request.DatePairs()
+	assert.Nil(t, err)
+	// Fridays 4, 11, 18 and 25 of March 2016, each returning on the Sunday or the Monday.
+	assert.Equal(t, 8, len(pairs))
+	assert.Equal(t, DatePair{utcDay(2016, 3, 4), utcDay(2016, 3, 6)}, pairs[0])
+	assert.Equal(t, DatePair{utcDay(2016, 3, 4), utcDay(2016, 3, 7)}, pairs[1])
+
+	request.ReturnDate = ""
+	pairs, err = request.DatePairs()
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(pairs))
+	assert.True(t, pairs[0].Return.IsZero())
+
+	request.MaxNights = 1
+	assert.Equal(t, "MaxNights", request.Validate().(*ValidationError).Field)
+	request.MaxNights = -1
+	assert.Equal(t, "MaxNights", request.Validate().(*ValidationError).Field)
+	request.MinNights = -1
+	assert.Equal(t, "MinNights", request.Validate().(*ValidationError).Field)
+}
+
+func TestCalendarSearch(t *testing.T) {
+	request := CalendarRequest{
+		Localisation:  Localisation{"GB", "GBP", "en-GB"},
+		Origin:        "LGW",
+		Destination:   "BCN",
+		DepartureDate: "20160819:20160820",
+		ReturnDate:    "20160821",
+		MinNights:     1}
+	pairs, _ := request.DatePairs()
+	first := pairs[0].BrowseRequest(&request).Url()
+	second := pairs[1].BrowseRequest(&request).Url()
+	engine := NewTestEngine(map[string]string{
+		first:  BrowseQuotesLocation,
+		second: CalendarRoutesLocation})
+	searcher := NewCalendarSearcher(engine)
+
+	results, err := searcher.Search(request)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, "BCN", results[0].Destination.SkyscannerCode)
+	grid := results[0].Grid
+	assert.Equal(t, []time.Time{utcDay(2016, 8, 19), utcDay(2016, 8, 20)}, grid.OutboundDates)
+	assert.Equal(t, "£64.00", grid.Get(utcDay(2016, 8, 19), utcDay(2016, 8, 21)).String())
+	assert.Equal(t, "£71.20", grid.Get(utcDay(2016, 8, 20), utcDay(2016, 8, 21)).String())
+	assert.Equal(t, NewDecimal(64), results[0].GetCheapest().Amount)
+
+	_, err = searcher.Search(request)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, engine.Calls[first])
+	assert.Equal(t, 1, engine.Calls[second])
+
+	cache := searcher.engine.(*CachedEngine).Cache.(*ExpiringStore)
+	cache.Now = func() time.Time { return time.Now().Add(calendarCacheTTL + time.Minute) }
+	_, err = searcher.Search(request)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, engine.Calls[first])
+
+	request.DepartureDate = anytime
+	request.ReturnDate = anytime
+	_, err = searcher.Search(request)
+	assert.Equal(t, "DepartureDate", err.(*ValidationError).Field)
+}
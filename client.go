@@ -5,134 +5,333 @@ import (
 	"io/ioutil"
 	"net/http"
 	"sort"
+	"sync"
 	"time"
 )
 
 const (
-	browseRouteFormat  = "http://partners.api.skyscanner.net/apiservices/browseroutes/v1.0/%s/%s/%s/%s/%s"
+	browseFormat       = "http://partners.api.skyscanner.net/apiservices/%s/v1.0/%s/%s/%s/%s"
+	browseRoutesPath   = "browseroutes"
+	browseQuotesPath   = "browsequotes"
+	browseDatesPath    = "browsedates"
+	browseGridPath     = "browsegrid"
 	browseRouteExample = "http://partners.api.skyscanner.net/apiservices/browseroutes/v1.0/GB/GBP/en-GB/LON/anywhere/20160819/20160821"
+	apiBaseURL         = "http://partners.api.skyscanner.net"
 	liveURL            = "http://partners.api.skyscanner.net/apiservices/pricing/v1.0"
+	localesURL         = "http://partners.api.skyscanner.net/apiservices/reference/v1.0/locales"
+	currenciesURL      = "http://partners.api.skyscanner.net/apiservices/reference/v1.0/currencies"
+	countriesFormat    = "http://partners.api.skyscanner.net/apiservices/reference/v1.0/countries/%s"
 	anywhere           = "anywhere"
 	linkBase           = "https://www.skyscanner.net/transport/flights/%s/%s/%s/%s/"
 	locationKey        = "Location"
-	apiKeyTag          = "?apiKey="
+	apiKeyParameter    = "apiKey="
+
+	defaultSearchConcurrency = 4
+	defaultPollInterval      = time.Second
+	defaultPollMaxRounds     = 60
 )
 
+// PollPolicy paces the polling of live sessions, giving up after MaxRounds polls, 60 when 0,
+// so that a session nobody waits for any more stops polling. Interval defaults to a second.
+type PollPolicy struct {
+	Interval  time.Duration
+	MaxRounds int
+}
+
+var DefaultPollPolicy = PollPolicy{Interval: defaultPollInterval}
+
+// SearchStatus reports how the live session of an origin and destination pair went.
+type SearchStatus struct {
+	Origin      string
+	Destination string
+	Itineraries int
+	Duration    time.Duration
+	Error       error
+}
+
 type RequestResults struct {
-	Error error
-	Data  *BrowseRoutesReply
+	Error   error
+	Data    *BrowseRoutesReply
+	Request BrowseRoutesRequest
 }
 
 func RunRequest(engine RequestEngine, r BrowseRoutesRequest) (*BrowseRoutesReply, error) {
 	url := r.Url()
-	fmt.Println(url)
+	fmt.Fprintln(Progress, url)
 	data, err := engine.Get(url)
 	if err != nil {
 		return nil, err
-	} else {
-		results := ParseBrowseRoutesReplyJson(data)
-		return results, nil
 	}
+	results := &BrowseRoutesReply{}
+	if err := ParseJson(data, results); err != nil {
+		return nil, fmt.Errorf("Invalid browse reply from %s: %s", url, err)
+	}
+	return results, nil
 
 }
 
 func Poll(url string) ([]byte, error) {
-	for {
+	return PollProgress(url, nil)
+}
+
+// PollProgress calls progress, when not nil, with every non empty poll round.
+func PollProgress(url string, progress PollCallback) ([]byte, error) {
+	return DefaultPollPolicy.Poll(url, progress)
+}
+
+func (m PollPolicy) Poll(url string, progress PollCallback) ([]byte, error) {
+	for round := 1; ; round++ {
+		if round > m.GetMaxRounds() {
+			return nil, fmt.Errorf("Live session still pending after %d polls", m.GetMaxRounds())
+		}
 		resp, err := http.Get(url)
 		if err != nil {
 			return nil, err
 		}
-		defer resp.Body.Close()
 		data, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 		if len(data) == 0 {
-			fmt.Println("Empty")
-			time.Sleep(1 * time.Second)
+			fmt.Fprintln(Progress, "Empty")
+			time.Sleep(m.GetInterval())
 			continue
 		}
-		if err != nil {
-			panic(err)
-		}
 		var reply LiveReply
 		err = ParseJson(data, &reply)
 		if err != nil {
-			fmt.Println(string(data))
-			panic(err)
+			return nil, fmt.Errorf("Invalid live reply: %s", err)
+		}
+		fmt.Fprintln(Progress, reply.Status)
+		if progress != nil {
+			progress(data)
 		}
-		fmt.Println(reply.Status)
 		if reply.Status == UpdatesCompleteStatus {
 			return data, nil
 		}
-		time.Sleep(1 * time.Second)
+		time.Sleep(m.GetInterval())
 	}
 
 }
 
-func runAndPost(request BrowseRoutesRequest, engine RequestEngine, channel chan RequestResults) {
-	data, err := RunRequest(engine, request)
-	channel <- RequestResults{err, data}
+func (m PollPolicy) GetInterval() time.Duration {
+	if m.Interval <= 0 {
+		return defaultPollInterval
+	}
+	return m.Interval
 }
 
-func LookForCountries(request BrowseRoutesRequest, countries []PlaceDto, engine RequestEngine) (FullQuotes, error) {
+func (m PollPolicy) GetMaxRounds() int {
+	if m.MaxRounds <= 0 {
+		return defaultPollMaxRounds
+	}
+	return m.MaxRounds
+}
 
-	countriesCount := len(countries)
-	results := make(FullQuotes, 0, countriesCount)
-	channel := make(chan RequestResults, countriesCount)
+func runAndPost(request BrowseRoutesRequest, engine RequestEngine, channel chan RequestResults) {
+	data, err := RunRequest(engine, request)
+	channel <- RequestResults{err, data, request}
+}
 
-	for _, place := range countries {
+// browseDestinations browses from the origin to each destination concurrently,
+// the results are in the order of the destinations.
+func browseDestinations(request BrowseRoutesRequest, destinations []PlaceDto, engine RequestEngine) ([]RequestResults, error) {
+	count := len(destinations)
+	channel := make(chan RequestResults, count)
+	for _, place := range destinations {
 		subRequest := BrowseRoutesRequest{request.Localisation, request.Origin, place.SkyscannerCode, request.DepartureDate, request.ReturnDate}
 		go runAndPost(subRequest, engine, channel)
 	}
-
-	for i := 0; i < countriesCount; i++ {
-		fmt.Printf("\rReceiving %d/%d", i, countriesCount)
+	byDestination := make(map[string]RequestResults)
+	for i := 0; i < count; i++ {
+		fmt.Fprintf(Progress, "\rReceiving %d/%d", i, count)
 		subResults := <-channel
 		if subResults.Error != nil {
-			return make(FullQuotes, 0, 0), subResults.Error
+			return nil, subResults.Error
 		}
-		results = append(results, subResults.Data.GetBestQuotes()...)
+		byDestination[subResults.Request.Destination] = subResults
 	}
-	fmt.Printf("\n")
+	fmt.Fprintf(Progress, "\n")
+	ordered := make([]RequestResults, count)
+	for index, place := range destinations {
+		ordered[index] = byDestination[place.SkyscannerCode]
+	}
+	return ordered, nil
+}
 
+func LookForCountries(request BrowseRoutesRequest, countries []PlaceDto, engine RequestEngine) (FullQuotes, error) {
+	subResults, err := browseDestinations(request, countries, engine)
+	if err != nil {
+		return make(FullQuotes, 0, 0), err
+	}
+	results := make(FullQuotes, 0, len(countries))
+	for _, subResult := range subResults {
+		results = append(results, subResult.Data.GetBestQuotesFor(request.IsReturn())...)
+	}
 	return results, nil
 }
 
+func RunLiveRequest(engine RequestEngine, request LiveRequest) (*FlightsData, error) {
+	return runLiveRequest(request, func() ([]byte, error) {
+		return engine.PostAndPoll(liveURL, request.Values())
+	})
+}
+
+func runLiveRequest(request LiveRequest, postAndPoll func() ([]byte, error)) (*FlightsData, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	data, err := postAndPoll()
+	if err != nil {
+		return nil, err
+	}
+	var reply LiveReply
+	err = ParseJson(data, &reply)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintln(Progress, "Results", reply.Stats())
+	if err := request.CheckQuery(&reply.Query); err != nil {
+		return nil, err
+	}
+	return ReadLiveReply(&reply)
+}
+
+// Search fails if any of the live sessions does, SearchWithStatus keeps the partial results.
 func Search(engine RequestEngine, arguments SearchRequest) (Itineraries, error) {
-	results := make(Itineraries, 0)
-	for _, destination := range arguments.Destinations {
-		fmt.Println("Searching", destination)
-		liveRequest := NewLiveRequest(
-			arguments.Localisation,
-			arguments.Origin,
-			destination,
-			arguments.DepartureDate,
-			arguments.ReturnDate)
-		data, err := engine.PostAndPoll(liveURL, liveRequest.Values())
-		if err != nil {
-			return nil, err
-		}
-		var reply LiveReply
-		err = ParseJson(data, &reply)
-		if err != nil {
-			return nil, err
+	results, statuses, err := SearchWithStatus(engine, arguments)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		if status.Error != nil {
+			return nil, status.Error
 		}
-		fmt.Println("Results", reply.Stats())
-		flightsData, err := ReadLiveReply(&reply)
-		if err != nil {
-			return nil, err
+	}
+	return results, nil
+}
+
+// SearchWithStatus runs the live sessions of every origin and destination pair concurrently.
+// Itineraries are merged in the order of the pairs, whatever order the sessions end in,
+// and the statuses are in that same order.
+func SearchWithStatus(engine RequestEngine, arguments SearchRequest) (Itineraries, []SearchStatus, error) {
+	return SearchWithProgress(engine, arguments, nil)
+}
+
+// SearchWithProgress is SearchWithStatus reporting the poll rounds of every session to progress, when not nil.
+func SearchWithProgress(engine RequestEngine, arguments SearchRequest, progress LiveProgressCallback) (Itineraries, []SearchStatus, error) {
+	if err := ValidateLocalisation(arguments.Localisation); err != nil {
+		return nil, nil, err
+	}
+	if err := arguments.OriginNearby.Validate("OriginNearby"); err != nil {
+		return nil, nil, err
+	}
+	if err := arguments.DestinationNearby.Validate("DestinationNearby"); err != nil {
+		return nil, nil, err
+	}
+	if arguments.Concurrency < 0 {
+		return nil, nil, &ValidationError{"Concurrency", "cannot be negative"}
+	}
+	reference := DefaultReference()
+	origins := reference.ExpandPlace(arguments.Origin, arguments.OriginNearby)
+	destinations := reference.ExpandDestinations(arguments.Destinations, arguments.DestinationNearby)
+	statuses := make([]SearchStatus, 0, len(origins)*len(destinations))
+	for _, origin := range origins {
+		for _, destination := range destinations {
+			if origin != destination {
+				statuses = append(statuses, SearchStatus{Origin: origin, Destination: destination})
+			}
 		}
-		results = append(results, flightsData.Itineraries...)
 	}
 
-	return results, nil
+	itineraries := make([]Itineraries, len(statuses))
+	slots := make(chan bool, arguments.GetConcurrency())
+	var group sync.WaitGroup
+	for index := range statuses {
+		group.Add(1)
+		go func(index int) {
+			defer group.Done()
+			slots <- true
+			defer func() { <-slots }()
+			itineraries[index] = runSearchSession(engine, arguments, &statuses[index], progress)
+		}(index)
+	}
+	group.Wait()
+
+	results := make(Itineraries, 0)
+	for _, sessionResults := range itineraries {
+		results = append(results, sessionResults...)
+	}
+	return results, statuses, nil
+}
+
+func runSearchSession(engine RequestEngine, arguments SearchRequest, status *SearchStatus, progress LiveProgressCallback) Itineraries {
+	fmt.Fprintln(Progress, "Searching", status.Origin, status.Destination)
+	start := time.Now()
+	liveRequest := NewLiveRequest(
+		arguments.Localisation,
+		status.Origin,
+		status.Destination,
+		arguments.DepartureDate,
+		arguments.ReturnDate,
+		arguments.LiveOptions)
+	flightsData, err := RunLiveRequestProgress(engine, liveRequest, progress)
+	status.Duration = time.Since(start)
+	if err != nil {
+		status.Error = err
+		return nil
+	}
+	for _, itinerary := range flightsData.Itineraries {
+		itinerary.SearchOrigin = status.Origin
+		itinerary.SearchDestination = status.Destination
+	}
+	status.Itineraries = len(flightsData.Itineraries)
+	return flightsData.Itineraries
+}
+
+func ListLocales(engine RequestEngine) ([]LocaleDto, error) {
+	var reply LocalesReply
+	if err := runReferenceRequest(engine, localesURL, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Locales, nil
+}
+
+func ListCurrencies(engine RequestEngine) ([]CurrencyDto, error) {
+	var reply CurrenciesReply
+	if err := runReferenceRequest(engine, currenciesURL, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Currencies, nil
+}
+
+func ListCountries(engine RequestEngine, locale string) ([]CountryDto, error) {
+	if len(locale) == 0 {
+		return nil, &ValidationError{"Locale", "missing"}
+	}
+	var reply CountriesReply
+	if err := runReferenceRequest(engine, fmt.Sprintf(countriesFormat, locale), &reply); err != nil {
+		return nil, err
+	}
+	return reply.Countries, nil
+}
+
+func runReferenceRequest(engine RequestEngine, url string, reply interface{}) error {
+	data, err := engine.Get(url)
+	if err != nil {
+		return err
+	}
+	return ParseReply(data, reply)
 }
 
 func Browse(engine RequestEngine, arguments BrowseRoutesRequest) (FullQuotes, error) {
+	if err := ValidateLocalisation(arguments.Localisation); err != nil {
+		return nil, err
+	}
 
 	request := NewBrowseRouteRequest(arguments.Localisation, arguments.Origin, arguments.DepartureDate, arguments.ReturnDate)
-	fmt.Println("Searching countries...")
+	fmt.Fprintln(Progress, "Searching countries...")
 	reply, err := RunRequest(engine, request)
 	if err != nil {
 		return nil, err
@@ -145,6 +344,6 @@ func Browse(engine RequestEngine, arguments BrowseRoutesRequest) (FullQuotes, er
 	sort.Sort(results)
 	towns := results.GetTowns()
 
-	fmt.Println("Searching towns...")
+	fmt.Fprintln(Progress, "Searching towns...")
 	return LookForCountries(request, towns, engine)
 }
@@ -2,7 +2,12 @@ package sklib
 
 import (
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
 	"testing"
+	"time"
 )
 
 const (
@@ -16,3 +21,117 @@ func TestKey(t *testing.T) {
 	assert.Equal(t, TestKeyValue, key)
 
 }
+
+// ConcurrencyEngine records how many requests run at the same time.
+type ConcurrencyEngine struct {
+	Engine  RequestEngine
+	running int
+	Max     int
+	lock    sync.Mutex
+}
+
+func (m *ConcurrencyEngine) Get(url string) ([]byte, error) {
+	return m.Engine.Get(url)
+}
+
+func (m *ConcurrencyEngine) PostAndPoll(url string, form url.Values) ([]byte, error) {
+	m.lock.Lock()
+	m.running++
+	if m.running > m.Max {
+		m.Max = m.running
+	}
+	m.lock.Unlock()
+	time.Sleep(20 * time.Millisecond)
+	m.lock.Lock()
+	m.running--
+	m.lock.Unlock()
+	return m.Engine.PostAndPoll(url, form)
+}
+
+func GetTestSearchEngine(origins []string) *TestEngine {
+	files := make(map[string]string)
+	for _, origin := range origins {
+		request := NewLiveRequest(Localisation{"GB", "GBP", "en-GB"}, origin, "VIE", "20161101", "", LiveOptions{})
+		files[liveURL+"?"+request.Values().Encode()] = LiveOneWayJsonLocation
+	}
+	return NewTestEngine(files)
+}
+
+func TestSearchConcurrency(t *testing.T) {
+	engine := &ConcurrencyEngine{Engine: GetTestSearchEngine([]string{"LCY", "LGW", "LHR", "LTN", "SEN", "STN"})}
+	request := SearchRequest{
+		Localisation:  Localisation{"GB", "GBP", "en-GB"},
+		Origin:        "LON",
+		Destinations:  []string{"VIE"},
+		DepartureDate: "20161101",
+		OriginNearby:  NearbyOptions{Mode: NearbyCity},
+		Concurrency:   2}
+	results, statuses, err := SearchWithStatus(engine, request)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, engine.Max)
+	assert.Equal(t, 6, len(statuses))
+	assert.Equal(t, 18, len(results))
+	for index, status := range statuses {
+		assert.Nil(t, status.Error)
+		assert.Equal(t, 3, status.Itineraries)
+		for _, itinerary := range results[index*3 : index*3+3] {
+			assert.Equal(t, status.Origin, itinerary.SearchOrigin)
+		}
+	}
+	assert.Equal(t, "LCY", statuses[0].Origin)
+	assert.Equal(t, "STN", statuses[5].Origin)
+
+	request.Concurrency = -1
+	_, _, err = SearchWithStatus(engine, request)
+	assert.Equal(t, "Concurrency", err.(*ValidationError).Field)
+}
+
+func TestSearchStatusErrors(t *testing.T) {
+	engine := GetTestSearchEngine([]string{"LGW", "LHR"})
+	request := SearchRequest{
+		Localisation:  Localisation{"GB", "GBP", "en-GB"},
+		Origin:        "LON",
+		Destinations:  []string{"VIE"},
+		DepartureDate: "20161101",
+		OriginNearby:  NearbyOptions{Mode: NearbyCity}}
+	results, statuses, err := SearchWithStatus(engine, request)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(results))
+	failed := 0
+	for _, status := range statuses {
+		if status.Error != nil {
+			failed++
+			assert.Equal(t, 0, status.Itineraries)
+		}
+	}
+	assert.Equal(t, 4, failed)
+
+	_, err = Search(engine, request)
+	assert.NotNil(t, err)
+}
+
+// ReplyEngine answers every request with the same payload.
+type ReplyEngine struct {
+	Payload []byte
+}
+
+func (m *ReplyEngine) Get(url string) ([]byte, error) {
+	return m.Payload, nil
+}
+
+func (m *ReplyEngine) PostAndPoll(url string, form url.Values) ([]byte, error) {
+	return m.Payload, nil
+}
+
+func TestInvalidReplies(t *testing.T) {
+	request := NewBrowseRouteRequest(Localisation{"GB", "GBP", "en-GB"}, "LOND", "2016-08-19", "2016-08-21")
+	_, err := RunRequest(&ReplyEngine{[]byte("<html>Bad gateway</html>")}, request)
+	assert.NotNil(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Write([]byte("<html>Bad gateway</html>"))
+	}))
+	defer server.Close()
+	_, err = PollPolicy{Interval: time.Millisecond}.Poll(server.URL, nil)
+	assert.NotNil(t, err)
+}
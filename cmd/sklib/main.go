@@ -0,0 +1,419 @@
+// Command sklib runs browse and live searches, lists reference data and manages the cache.
+//
+//	sklib browse -origin LOND -departure 2016-08-19 -return 2016-08-21
+//	sklib search -origin LOND -destinations PARI,ROME -departure 2016-08-19 -direct
+//	sklib locales | currencies | countries -locale en-GB
+//	sklib cache count | clear
+//
+// Every command takes -format table, json or csv, and -config, a JSON sklib.Config.
+// The SKLIB_ environment variables override the config, and the flags set override both.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	// Embeds the zone database, for hosts without zoneinfo such as scratch containers or Windows.
+	_ "time/tzdata"
+
+	"github.com/arthurandres/sklib"
+)
+
+const (
+	dateTimeDisplay = "2006-01-02 15:04"
+	clockFormat     = "15:04"
+)
+
+type command func(args []string, writer io.Writer) error
+
+var commands = map[string]command{
+	"browse":     runBrowse,
+	"search":     runSearch,
+	"locales":    runLocales,
+	"currencies": runCurrencies,
+	"countries":  runCountries,
+	"cache":      runCache,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	run, exists := commands[os.Args[1]]
+	if !exists {
+		fmt.Fprintf(os.Stderr, "Unknown command %s\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+	// Keep stdout for the output, so it can be piped.
+	sklib.Progress = os.Stderr
+	if err := run(os.Args[2:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(os.Stderr, "Usage: sklib <%s> [flags]\n", strings.Join(names, "|"))
+}
+
+// commonFlags are shared by the commands calling the API.
+type commonFlags struct {
+	flags    *flag.FlagSet
+	format   string
+	config   string
+	key      string
+	noCache  bool
+	country  string
+	currency string
+	locale   string
+}
+
+func addCommonFlags(flags *flag.FlagSet) *commonFlags {
+	result := &commonFlags{flags: flags}
+	defaults := sklib.DefaultConfig()
+	flags.StringVar(&result.format, "format", tableFormat, "output format: table, json or csv")
+	flags.StringVar(&result.config, "config", "", "JSON config file")
+	flags.StringVar(&result.key, "key", defaults.KeyFile, "file holding the API key")
+	flags.BoolVar(&result.noCache, "no-cache", false, "ignore cached replies, still caching the new ones")
+	flags.StringVar(&result.country, "country", defaults.Localisation.Country, "market country")
+	flags.StringVar(&result.currency, "currency", defaults.Localisation.Currency, "currency of the prices")
+	flags.StringVar(&result.locale, "locale", defaults.Localisation.Language, "locale of the names")
+	return result
+}
+
+// loadConfig applies the flags set on the command line over the config.
+func (m *commonFlags) loadConfig() (sklib.Config, error) {
+	config, err := sklib.LoadConfig(m.config)
+	if err != nil {
+		return config, err
+	}
+	m.flags.Visit(func(set *flag.Flag) {
+		switch set.Name {
+		case "key":
+			config.Key, config.KeyFile = "", m.key
+		case "no-cache":
+			config.NoCache = m.noCache
+		case "country":
+			config.Localisation.Country = m.country
+		case "currency":
+			config.Localisation.Currency = m.currency
+		case "locale":
+			config.Localisation.Language = m.locale
+		}
+	})
+	return config, nil
+}
+
+// run calls the API and writes the result, closing the cache once done.
+// Requests leave their localisation empty, for the configured one to apply.
+func (m *commonFlags) run(writer io.Writer, call func(api sklib.SearchAPI, config sklib.Config) (output, error)) error {
+	if err := validateFormat(m.format); err != nil {
+		return err
+	}
+	config, err := m.loadConfig()
+	if err != nil {
+		return err
+	}
+	api, closeCache, err := sklib.NewConfiguredSearchAPI(config)
+	if err != nil {
+		return err
+	}
+	defer closeCache()
+	result, err := call(api, config)
+	if err != nil {
+		return err
+	}
+	return writeOutput(writer, m.format, result)
+}
+
+// clockFlag is an optional time of the day, as hours and minutes.
+type clockFlag struct {
+	value *time.Duration
+}
+
+func (m *clockFlag) String() string {
+	if m.value == nil {
+		return ""
+	}
+	return time.Time{}.Add(*m.value).Format(clockFormat)
+}
+
+func (m *clockFlag) Set(input string) error {
+	clock, err := time.Parse(clockFormat, input)
+	if err != nil {
+		return fmt.Errorf("Invalid time %s, expected hh:mm", input)
+	}
+	value := time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute
+	m.value = &value
+	return nil
+}
+
+// dateFlag is an optional date, given as yyyy-mm-dd and sent to the API as yyyymmdd.
+type dateFlag struct {
+	value *time.Time
+}
+
+func (m *dateFlag) String() string {
+	if m.value == nil {
+		return ""
+	}
+	return m.value.Format(sklib.DateFormatForm)
+}
+
+func (m *dateFlag) Set(input string) error {
+	date, err := time.Parse(sklib.DateFormatForm, input)
+	if err != nil {
+		return fmt.Errorf("Invalid date %s, expected yyyy-mm-dd", input)
+	}
+	m.value = &date
+	return nil
+}
+
+// urlDate is empty when the date is not set, for one-way requests.
+func (m *dateFlag) urlDate() string {
+	if m.value == nil {
+		return ""
+	}
+	return m.value.Format(sklib.DateFormatUrl)
+}
+
+// addDateFlags adds the departure and return dates shared by browse and search.
+func addDateFlags(flags *flag.FlagSet) (*dateFlag, *dateFlag) {
+	departure, inbound := &dateFlag{}, &dateFlag{}
+	flags.Var(departure, "departure", "departure date, yyyy-mm-dd")
+	flags.Var(inbound, "return", "return date, yyyy-mm-dd, empty for one-way")
+	return departure, inbound
+}
+
+func runBrowse(args []string, writer io.Writer) error {
+	flags := flag.NewFlagSet("browse", flag.ExitOnError)
+	common := addCommonFlags(flags)
+	origin := flags.String("origin", "", "origin place code")
+	departure, inbound := addDateFlags(flags)
+	direct := flags.Bool("direct", false, "direct quotes only")
+	limit := flags.Int("limit", 0, "maximum number of quotes, 0 for all")
+	flags.Parse(args)
+	request := sklib.NewBrowseRouteRequest(sklib.Localisation{}, *origin, departure.urlDate(), inbound.urlDate())
+	return common.run(writer, func(api sklib.SearchAPI, config sklib.Config) (output, error) {
+		quotes, err := api.Browse(request)
+		if err != nil {
+			return output{}, err
+		}
+		if *direct {
+			quotes = quotes.FilterDirects()
+		}
+		if *limit > 0 && len(quotes) > *limit {
+			quotes = quotes[:*limit]
+		}
+		return quotesOutput(quotes), nil
+	})
+}
+
+func quotesOutput(quotes sklib.FullQuotes) output {
+	rows := make([][]string, len(quotes))
+	for index, quote := range quotes {
+		rows[index] = []string{
+			quote.Destination.SkyscannerCode,
+			quote.Destination.Name,
+			quote.GetMoney().String(),
+			strconv.FormatBool(quote.Quote.Direct),
+			quote.Quote.OutboundLeg.DepartureDate,
+			quote.Quote.InboundLeg.DepartureDate}
+	}
+	return output{
+		Header: []string{"Code", "Destination", "Price", "Direct", "Departure", "Return"},
+		Rows:   rows,
+		Value:  quotes}
+}
+
+func runSearch(args []string, writer io.Writer) error {
+	flags := flag.NewFlagSet("search", flag.ExitOnError)
+	common := addCommonFlags(flags)
+	origin := flags.String("origin", "", "origin place code")
+	destinations := flags.String("destinations", "", "comma separated destination place codes")
+	departure, inbound := addDateFlags(flags)
+	adults := flags.Int("adults", 1, "number of adults")
+	direct := flags.Bool("direct", false, "direct itineraries only")
+	sortBy := flags.String("sort", sklib.SortByPrice, "sort by price, departure or duration")
+	limit := flags.Int("limit", 0, "maximum number of itineraries, 0 for all")
+	var outboundAfter, outboundBefore, inboundAfter, inboundBefore clockFlag
+	flags.Var(&outboundAfter, "outbound-after", "earliest outbound departure, hh:mm")
+	flags.Var(&outboundBefore, "outbound-before", "latest outbound departure, hh:mm")
+	flags.Var(&inboundAfter, "inbound-after", "earliest inbound departure, hh:mm")
+	flags.Var(&inboundBefore, "inbound-before", "latest inbound departure, hh:mm")
+	flags.Parse(args)
+
+	request := searchRequest(*origin, *destinations, departure, inbound, *adults)
+	filter := make(sklib.CompositeFilter, 0)
+	if *direct {
+		filter = filter.AppendDirectOnly()
+	}
+	filter = filter.AppendTimeFilter(outboundAfter.value, false, true)
+	filter = filter.AppendTimeFilter(outboundBefore.value, true, true)
+	filter = filter.AppendTimeFilter(inboundAfter.value, false, false)
+	filter = filter.AppendTimeFilter(inboundBefore.value, true, false)
+	options := sklib.SearchOptions{SortBy: *sortBy, Limit: *limit}
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	return common.run(writer, func(api sklib.SearchAPI, config sklib.Config) (output, error) {
+		results, err := api.Search(request, sklib.SearchOptions{})
+		if err != nil {
+			return output{}, err
+		}
+		return itinerariesOutput(options.Apply(sklib.ApplyFilter(results, filter))), nil
+	})
+}
+
+func searchRequest(origin string, destinations string, departure *dateFlag, inbound *dateFlag, adults int) sklib.SearchRequest {
+	return sklib.SearchRequest{
+		Origin:        origin,
+		Destinations:  splitCodes(destinations),
+		DepartureDate: departure.urlDate(),
+		ReturnDate:    inbound.urlDate(),
+		LiveOptions:   sklib.LiveOptions{Adults: adults}}
+}
+
+func splitCodes(input string) []string {
+	results := make([]string, 0)
+	for _, code := range strings.Split(input, ",") {
+		if code = strings.TrimSpace(code); len(code) != 0 {
+			results = append(results, code)
+		}
+	}
+	return results
+}
+
+func itinerariesOutput(itineraries sklib.Itineraries) output {
+	rows := make([][]string, len(itineraries))
+	for index, itinerary := range itineraries {
+		row := make([]string, 0, 9)
+		for _, leg := range []*sklib.Leg{itinerary.OutboundLeg, itinerary.InboundLeg} {
+			if leg == nil {
+				row = append(row, "", "", "", "")
+				continue
+			}
+			row = append(row,
+				leg.Origin.Code+"-"+leg.Destination.Code,
+				leg.Departure.Format(dateTimeDisplay),
+				leg.Arrival.Format(dateTimeDisplay),
+				strconv.Itoa(len(leg.Stops)))
+		}
+		rows[index] = append(row, itinerary.GetMoney().String())
+	}
+	return output{
+		Header: []string{
+			"Outbound", "Departure", "Arrival", "Stops",
+			"Inbound", "Departure", "Arrival", "Stops",
+			"Price"},
+		Rows:  rows,
+		Value: itineraries}
+}
+
+func runLocales(args []string, writer io.Writer) error {
+	flags := flag.NewFlagSet("locales", flag.ExitOnError)
+	common := addCommonFlags(flags)
+	flags.Parse(args)
+	return common.run(writer, func(api sklib.SearchAPI, config sklib.Config) (output, error) {
+		locales, err := api.ListLocales()
+		if err != nil {
+			return output{}, err
+		}
+		rows := make([][]string, len(locales))
+		for index, locale := range locales {
+			rows[index] = []string{locale.Code, locale.Name}
+		}
+		return output{[]string{"Code", "Name"}, rows, locales}, nil
+	})
+}
+
+func runCurrencies(args []string, writer io.Writer) error {
+	flags := flag.NewFlagSet("currencies", flag.ExitOnError)
+	common := addCommonFlags(flags)
+	flags.Parse(args)
+	return common.run(writer, func(api sklib.SearchAPI, config sklib.Config) (output, error) {
+		currencies, err := api.ListCurrencies()
+		if err != nil {
+			return output{}, err
+		}
+		rows := make([][]string, len(currencies))
+		for index, currency := range currencies {
+			rows[index] = []string{currency.Code, currency.Symbol}
+		}
+		return output{[]string{"Code", "Symbol"}, rows, currencies}, nil
+	})
+}
+
+func runCountries(args []string, writer io.Writer) error {
+	flags := flag.NewFlagSet("countries", flag.ExitOnError)
+	common := addCommonFlags(flags)
+	flags.Parse(args)
+	return common.run(writer, func(api sklib.SearchAPI, config sklib.Config) (output, error) {
+		countries, err := api.ListCountries(config.Localisation.Language)
+		if err != nil {
+			return output{}, err
+		}
+		rows := make([][]string, len(countries))
+		for index, country := range countries {
+			rows[index] = []string{country.Code, country.Name}
+		}
+		return output{[]string{"Code", "Name"}, rows, countries}, nil
+	})
+}
+
+// runCache counts or clears the entries cached with and without TTL.
+func runCache(args []string, writer io.Writer) error {
+	flags := flag.NewFlagSet("cache", flag.ExitOnError)
+	format := flags.String("format", tableFormat, "output format: table, json or csv")
+	configFile := flags.String("config", "", "JSON config file")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		return fmt.Errorf("Usage: sklib cache [flags] <count|clear>")
+	}
+	if err := validateFormat(*format); err != nil {
+		return err
+	}
+	config, err := sklib.LoadConfig(*configFile)
+	if err != nil {
+		return err
+	}
+	db, err := sklib.OpenDB(config.CachePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	buckets := sklib.CreateCacheBuckets(db)
+	switch flags.Arg(0) {
+	case "count":
+		total := 0
+		for _, bucket := range buckets {
+			count, err := bucket.Count()
+			if err != nil {
+				return err
+			}
+			total += count
+		}
+		return writeOutput(writer, *format, output{[]string{"Entries"}, [][]string{{strconv.Itoa(total)}}, total})
+	case "clear":
+		for _, bucket := range buckets {
+			if err := bucket.Clear(); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("Unknown cache command %s, expected count or clear", flags.Arg(0))
+	}
+}
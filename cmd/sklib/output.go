@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	tableFormat = "table"
+	jsonFormat  = "json"
+	csvFormat   = "csv"
+)
+
+// output is the result of a command: rows for the table and CSV formats, the value itself for JSON.
+type output struct {
+	Header []string
+	Rows   [][]string
+	Value  interface{}
+}
+
+func validateFormat(format string) error {
+	switch format {
+	case tableFormat, jsonFormat, csvFormat:
+		return nil
+	default:
+		return fmt.Errorf("Unknown format %s, expected %s, %s or %s", format, tableFormat, jsonFormat, csvFormat)
+	}
+}
+
+func writeOutput(writer io.Writer, format string, result output) error {
+	switch format {
+	case jsonFormat:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result.Value)
+	case csvFormat:
+		csvWriter := csv.NewWriter(writer)
+		if err := csvWriter.Write(result.Header); err != nil {
+			return err
+		}
+		if err := csvWriter.WriteAll(result.Rows); err != nil {
+			return err
+		}
+		return csvWriter.Error()
+	case tableFormat:
+		tableWriter := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tableWriter, strings.Join(result.Header, "\t"))
+		for _, row := range result.Rows {
+			fmt.Fprintln(tableWriter, strings.Join(row, "\t"))
+		}
+		return tableWriter.Flush()
+	default:
+		return validateFormat(format)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/arthurandres/sklib"
+	"github.com/stretchr/testify/assert"
+)
+
+func testOutput() output {
+	return output{
+		Header: []string{"Code", "Name"},
+		Rows:   [][]string{{"GB", "United Kingdom"}, {"FR", "France, Republic"}},
+		Value:  map[string]string{"GB": "United Kingdom"}}
+}
+
+func TestWriteOutput(t *testing.T) {
+	var buffer bytes.Buffer
+	assert.Nil(t, writeOutput(&buffer, tableFormat, testOutput()))
+	assert.Equal(t, "Code  Name\nGB    United Kingdom\nFR    France, Republic\n", buffer.String())
+
+	buffer.Reset()
+	assert.Nil(t, writeOutput(&buffer, csvFormat, testOutput()))
+	assert.Equal(t, "Code,Name\nGB,United Kingdom\nFR,\"France, Republic\"\n", buffer.String())
+
+	buffer.Reset()
+	assert.Nil(t, writeOutput(&buffer, jsonFormat, testOutput()))
+	assert.Equal(t, "{\n  \"GB\": \"United Kingdom\"\n}\n", buffer.String())
+
+	assert.NotNil(t, writeOutput(&buffer, "xml", testOutput()))
+}
+
+func TestClockFlag(t *testing.T) {
+	var clock clockFlag
+	assert.Equal(t, "", clock.String())
+	assert.Nil(t, clock.Set("08:30"))
+	assert.Equal(t, 8*time.Hour+30*time.Minute, *clock.value)
+	assert.Equal(t, "08:30", clock.String())
+	assert.NotNil(t, clock.Set("8h30"))
+}
+
+func TestDateFlags(t *testing.T) {
+	flags := flag.NewFlagSet("search", flag.ContinueOnError)
+	departure, inbound := addDateFlags(flags)
+	assert.Nil(t, flags.Parse([]string{"-departure", "2016-08-19", "-return", "2016-08-21"}))
+	assert.Equal(t, "2016-08-19", departure.String())
+
+	request := searchRequest("LOND", "PARI", departure, inbound, 1)
+	assert.Equal(t, "20160819", request.DepartureDate)
+	assert.Equal(t, "20160821", request.ReturnDate)
+	live := sklib.NewLiveRequest(
+		sklib.Localisation{Country: "GB", Currency: "GBP", Language: "en-GB"},
+		request.Origin,
+		request.Destinations[0],
+		request.DepartureDate,
+		request.ReturnDate,
+		request.LiveOptions)
+	assert.Nil(t, live.Validate())
+
+	flags = flag.NewFlagSet("search", flag.ContinueOnError)
+	departure, inbound = addDateFlags(flags)
+	assert.Nil(t, flags.Parse([]string{"-departure", "2016-08-19"}))
+	assert.Equal(t, "", searchRequest("LOND", "PARI", departure, inbound, 1).ReturnDate)
+	assert.NotNil(t, departure.Set("20160819"))
+}
+
+func TestSplitCodes(t *testing.T) {
+	assert.Equal(t, []string{"PARI", "ROME"}, splitCodes("PARI, ROME,"))
+	assert.Equal(t, []string{}, splitCodes(""))
+}
@@ -0,0 +1,219 @@
+package sklib
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultKeyLocation = "key"
+	envPrefix          = "SKLIB_"
+)
+
+// Config wires a RequestEngine and a SearchAPI. It is read from a JSON file,
+// then overridden by the SKLIB_ environment variables, e.g. SKLIB_KEY or SKLIB_CACHE_TTL.
+// Key takes precedence over KeyFile. A CacheTTL of 0 keeps the cached replies forever,
+// a RequestInterval of 0 does not throttle, and BaseURL replaces the partners API host.
+// ReferenceTTL only applies when CacheTTL is set: the reference data is refetched through
+// the cache, so it is never fresher than the CacheTTL allows.
+type Config struct {
+	Key             string
+	KeyFile         string
+	Localisation    Localisation
+	CachePath       string
+	NoCache         bool
+	CacheTTL        ConfigDuration
+	ReferenceTTL    ConfigDuration
+	RequestInterval ConfigDuration
+	Concurrency     int
+	PollInterval    ConfigDuration
+	PollMaxRounds   int
+	BaseURL         string
+}
+
+// ConfigDuration reads durations such as "90s" or "24h" from JSON.
+type ConfigDuration struct {
+	time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		KeyFile:      defaultKeyLocation,
+		Localisation: Localisation{"GB", "GBP", "en-GB"},
+		CachePath:    cacheLocation,
+		ReferenceTTL: ConfigDuration{referenceTTL},
+		Concurrency:  defaultSearchConcurrency,
+		PollInterval: ConfigDuration{defaultPollInterval}}
+}
+
+// LoadConfig starts from DefaultConfig, and skips the file when fileName is empty.
+func LoadConfig(fileName string) (Config, error) {
+	config := DefaultConfig()
+	if len(fileName) != 0 {
+		file, err := os.Open(fileName)
+		if err != nil {
+			return config, fmt.Errorf("Could not read config from %s: %s", fileName, err)
+		}
+		defer file.Close()
+		decoder := json.NewDecoder(file)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&config); err != nil {
+			return config, fmt.Errorf("Invalid config %s: %s", fileName, err)
+		}
+	}
+	if err := config.ApplyEnvironment(os.LookupEnv); err != nil {
+		return config, err
+	}
+	return config, config.Validate()
+}
+
+// ApplyEnvironment overrides the fields set in the environment, lookup being os.LookupEnv outside of tests.
+func (m *Config) ApplyEnvironment(lookup func(key string) (string, bool)) error {
+	texts := map[string]*string{
+		"KEY":        &m.Key,
+		"KEY_FILE":   &m.KeyFile,
+		"COUNTRY":    &m.Localisation.Country,
+		"CURRENCY":   &m.Localisation.Currency,
+		"LOCALE":     &m.Localisation.Language,
+		"CACHE_PATH": &m.CachePath,
+		"BASE_URL":   &m.BaseURL}
+	for name, field := range texts {
+		if value, exists := lookup(envPrefix + name); exists {
+			*field = value
+		}
+	}
+	integers := map[string]*int{
+		"CONCURRENCY":     &m.Concurrency,
+		"POLL_MAX_ROUNDS": &m.PollMaxRounds}
+	for name, field := range integers {
+		if value, exists := lookup(envPrefix + name); exists {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return &ValidationError{envPrefix + name, fmt.Sprintf("%s is not a number", value)}
+			}
+			*field = parsed
+		}
+	}
+	durations := map[string]*ConfigDuration{
+		"CACHE_TTL":        &m.CacheTTL,
+		"REFERENCE_TTL":    &m.ReferenceTTL,
+		"REQUEST_INTERVAL": &m.RequestInterval,
+		"POLL_INTERVAL":    &m.PollInterval}
+	for name, field := range durations {
+		if value, exists := lookup(envPrefix + name); exists {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				return &ValidationError{envPrefix + name, fmt.Sprintf("%s is not a duration", value)}
+			}
+			field.Duration = parsed
+		}
+	}
+	if value, exists := lookup(envPrefix + "NO_CACHE"); exists {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return &ValidationError{envPrefix + "NO_CACHE", fmt.Sprintf("%s is not a boolean", value)}
+		}
+		m.NoCache = parsed
+	}
+	return nil
+}
+
+func (m *Config) Validate() error {
+	if len(m.Key) == 0 && len(m.KeyFile) == 0 {
+		return &ValidationError{"Key", "missing, and no KeyFile to read it from"}
+	}
+	if len(m.CachePath) == 0 {
+		return &ValidationError{"CachePath", "missing"}
+	}
+	if m.Concurrency < 0 {
+		return &ValidationError{"Concurrency", "cannot be negative"}
+	}
+	if m.PollMaxRounds < 0 {
+		return &ValidationError{"PollMaxRounds", "cannot be negative"}
+	}
+	durations := map[string]ConfigDuration{
+		"CacheTTL":        m.CacheTTL,
+		"ReferenceTTL":    m.ReferenceTTL,
+		"RequestInterval": m.RequestInterval,
+		"PollInterval":    m.PollInterval}
+	for name, duration := range durations {
+		if duration.Duration < 0 {
+			return &ValidationError{name, "cannot be negative"}
+		}
+	}
+	if len(m.BaseURL) != 0 && !strings.HasPrefix(m.BaseURL, "http://") && !strings.HasPrefix(m.BaseURL, "https://") {
+		return &ValidationError{"BaseURL", fmt.Sprintf("%s is not an http URL", m.BaseURL)}
+	}
+	return nil
+}
+
+func (m *Config) GetKey() (string, error) {
+	if len(m.Key) != 0 {
+		return m.Key, nil
+	}
+	return LoadKey(m.KeyFile)
+}
+
+// NewConfiguredEngine stacks the cache over the throttling over the live engine,
+// the returned function closes the cache.
+func NewConfiguredEngine(config Config) (RequestEngine, func() error, error) {
+	if err := config.Validate(); err != nil {
+		return nil, nil, err
+	}
+	key, err := config.GetKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	var engine RequestEngine = &LiveEngine{
+		Key:     key,
+		BaseURL: config.BaseURL,
+		Poll:    PollPolicy{config.PollInterval.Duration, config.PollMaxRounds}}
+	if config.RequestInterval.Duration > 0 {
+		engine = &ThrottledEngine{Engine: engine, Interval: config.RequestInterval.Duration}
+	}
+	db, err := OpenDB(config.CachePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	var cache CacheStore = CreateCache(db)
+	if config.CacheTTL.Duration > 0 {
+		cache = CreateExpiringCache(db, config.CacheTTL.Duration)
+	}
+	if config.NoCache {
+		cache = &WriteOnlyStore{Store: cache}
+	}
+	return &CachedEngine{engine, cache}, db.Close, nil
+}
+
+func NewConfiguredSearchAPI(config Config) (*EngineSearchAPI, func() error, error) {
+	engine, closeCache, err := NewConfiguredEngine(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &EngineSearchAPI{
+		engine:       engine,
+		localisation: config.Localisation,
+		concurrency:  config.Concurrency,
+		referenceTTL: config.ReferenceTTL.Duration}, closeCache, nil
+}
+
+func (m *ConfigDuration) UnmarshalJSON(data []byte) error {
+	var input string
+	if err := json.Unmarshal(data, &input); err != nil {
+		return fmt.Errorf("Invalid duration %s, expected a string such as \"24h\"", data)
+	}
+	duration, err := time.ParseDuration(input)
+	if err != nil {
+		return fmt.Errorf("Invalid duration %s", input)
+	}
+	m.Duration = duration
+	return nil
+}
+
+func (m ConfigDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Duration.String())
+}
@@ -0,0 +1,147 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTempFile(t *testing.T, directory string, name string, content string) string {
+	fileName := filepath.Join(directory, name)
+	assert.Nil(t, ioutil.WriteFile(fileName, []byte(content), 0600))
+	return fileName
+}
+
+func TestLoadConfig(t *testing.T) {
+	directory, err := ioutil.TempDir("", "sklib")
+	assert.Nil(t, err)
+	defer os.RemoveAll(directory)
+	fileName := writeTempFile(t, directory, "config.json", `{
+		"Key": "secret",
+		"Localisation": {"Country": "FR", "Currency": "EUR", "Language": "fr-FR"},
+		"CacheTTL": "24h",
+		"PollMaxRounds": 30}`)
+
+	config, err := LoadConfig(fileName)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", config.Key)
+	assert.Equal(t, Localisation{"FR", "EUR", "fr-FR"}, config.Localisation)
+	assert.Equal(t, 24*time.Hour, config.CacheTTL.Duration)
+	assert.Equal(t, 30, config.PollMaxRounds)
+	assert.Equal(t, cacheLocation, config.CachePath)
+	assert.Equal(t, time.Second, config.PollInterval.Duration)
+
+	_, err = LoadConfig(writeTempFile(t, directory, "unknown.json", `{"Unknown": 1}`))
+	assert.NotNil(t, err)
+	_, err = LoadConfig(writeTempFile(t, directory, "duration.json", `{"CacheTTL": 3600}`))
+	assert.NotNil(t, err)
+	_, err = LoadConfig(filepath.Join(directory, "missing.json"))
+	assert.NotNil(t, err)
+}
+
+func TestApplyEnvironment(t *testing.T) {
+	environment := map[string]string{
+		"SKLIB_KEY":              "secret",
+		"SKLIB_CURRENCY":         "EUR",
+		"SKLIB_CONCURRENCY":      "8",
+		"SKLIB_REQUEST_INTERVAL": "250ms",
+		"SKLIB_NO_CACHE":         "true"}
+	lookup := func(key string) (string, bool) {
+		value, exists := environment[key]
+		return value, exists
+	}
+	config := DefaultConfig()
+	assert.Nil(t, config.ApplyEnvironment(lookup))
+	assert.Equal(t, "secret", config.Key)
+	assert.Equal(t, Localisation{"GB", "EUR", "en-GB"}, config.Localisation)
+	assert.Equal(t, 8, config.Concurrency)
+	assert.Equal(t, 250*time.Millisecond, config.RequestInterval.Duration)
+	assert.True(t, config.NoCache)
+
+	environment["SKLIB_CONCURRENCY"] = "eight"
+	err := config.ApplyEnvironment(lookup)
+	assert.Equal(t, &ValidationError{"SKLIB_CONCURRENCY", "eight is not a number"}, err)
+}
+
+func TestValidateConfig(t *testing.T) {
+	config := DefaultConfig()
+	assert.Nil(t, config.Validate())
+	config.BaseURL = "localhost:8080"
+	assert.NotNil(t, config.Validate())
+	config = DefaultConfig()
+	config.KeyFile = ""
+	assert.NotNil(t, config.Validate())
+	config = DefaultConfig()
+	config.PollInterval = ConfigDuration{-time.Second}
+	assert.Equal(t, &ValidationError{"PollInterval", "cannot be negative"}, config.Validate())
+}
+
+func TestNewConfiguredEngine(t *testing.T) {
+	directory, err := ioutil.TempDir("", "sklib")
+	assert.Nil(t, err)
+	defer os.RemoveAll(directory)
+	config := DefaultConfig()
+	config.KeyFile = filepath.Join(directory, "missing")
+	config.CachePath = filepath.Join(directory, cacheLocation)
+	_, _, err = NewConfiguredEngine(config)
+	assert.NotNil(t, err)
+
+	config.KeyFile = writeTempFile(t, directory, "key", "secret\n")
+	config.CacheTTL = ConfigDuration{time.Hour}
+	config.RequestInterval = ConfigDuration{time.Second}
+	config.BaseURL = "http://localhost:8080"
+	api, closeCache, err := NewConfiguredSearchAPI(config)
+	assert.Nil(t, err)
+	defer closeCache()
+	cached := api.engine.(*CachedEngine)
+	assert.IsType(t, &ExpiringStore{}, cached.Cache)
+	throttled := cached.Engine.(*ThrottledEngine)
+	live := throttled.Engine.(*LiveEngine)
+	assert.Equal(t, "secret", live.Key)
+	assert.Equal(t, "http://localhost:8080/apiservices/pricing/v1.0", live.rebase(liveURL))
+	assert.Equal(t, "http://other.net/path", live.rebase("http://other.net/path"))
+	assert.Equal(t, Localisation{"FR", "GBP", "en-GB"}, api.localise(Localisation{Country: "FR"}))
+}
+
+func TestThrottledEngine(t *testing.T) {
+	engine := &ThrottledEngine{Engine: NewTestEngine(map[string]string{"url": LocalesLocation}), Interval: 20 * time.Millisecond}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := engine.Get("url")
+		assert.Nil(t, err)
+	}
+	assert.True(t, time.Since(start) >= 40*time.Millisecond)
+}
+
+func TestPollPolicyMaxRounds(t *testing.T) {
+	pending := ReadOrPanic(LivePendingJsonLocation)
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		polls++
+		writer.Write(pending)
+	}))
+	defer server.Close()
+	_, err := PollPolicy{Interval: time.Millisecond, MaxRounds: 3}.Poll(server.URL, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, defaultPollMaxRounds, PollPolicy{}.GetMaxRounds())
+}
+
+func TestLoadKey(t *testing.T) {
+	directory, err := ioutil.TempDir("", "sklib")
+	assert.Nil(t, err)
+	defer os.RemoveAll(directory)
+	key, err := LoadKey(writeTempFile(t, directory, "key", " secret\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", key)
+	_, err = LoadKey(writeTempFile(t, directory, "empty", "\n"))
+	assert.NotNil(t, err)
+	_, err = LoadKey(filepath.Join(directory, "missing"))
+	assert.NotNil(t, err)
+	assert.Panics(t, func() { ReadKey(filepath.Join(directory, "missing")) })
+}
@@ -0,0 +1,331 @@
+package sklib
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	ratesDateFormat = "2006-01-02"
+	rateDigits      = 10
+)
+
+var ratesColumns = []string{"date", "from", "to", "rate"}
+
+// CurrencyConverter gives the rate to multiply an amount in one currency by to get it in another.
+type CurrencyConverter interface {
+	Rate(from string, to string) (*Conversion, error)
+}
+
+// Conversion records the rate used to convert a price, and the date that rate was published.
+type Conversion struct {
+	From string
+	To   string
+	Rate ExchangeRate
+	Date time.Time
+}
+
+// ExchangeRate is exact, as the 4 digits of a Decimal round the rate of a high-value pair,
+// such as VND to GBP, to 0.
+type ExchangeRate struct {
+	value *big.Rat
+}
+
+func NewExchangeRate(units int64) ExchangeRate {
+	return ExchangeRate{big.NewRat(units, 1)}
+}
+
+// ParseExchangeRate keeps every digit of the input.
+func ParseExchangeRate(input string) (ExchangeRate, error) {
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(input))
+	if !ok {
+		return ExchangeRate{}, fmt.Errorf("Invalid rate %s", input)
+	}
+	return ExchangeRate{value}, nil
+}
+
+func (m ExchangeRate) rat() *big.Rat {
+	if m.value == nil {
+		return new(big.Rat)
+	}
+	return m.value
+}
+
+func (m ExchangeRate) Sign() int {
+	return m.rat().Sign()
+}
+
+func (m ExchangeRate) Mul(other ExchangeRate) ExchangeRate {
+	return ExchangeRate{new(big.Rat).Mul(m.rat(), other.rat())}
+}
+
+func (m ExchangeRate) Inverse() (ExchangeRate, error) {
+	if m.Sign() == 0 {
+		return ExchangeRate{}, fmt.Errorf("Cannot invert a zero rate")
+	}
+	return ExchangeRate{new(big.Rat).Inv(m.rat())}, nil
+}
+
+// Apply converts an amount, rounding half away from zero to the digits of a Decimal.
+func (m ExchangeRate) Apply(amount Decimal) Decimal {
+	if amount == MaxDecimal {
+		return amount
+	}
+	numerator := new(big.Int).Mul(big.NewInt(int64(amount)), m.rat().Num())
+	return divideRounded(numerator, m.rat().Denom())
+}
+
+// String rounds to 10 decimal digits, dropping the trailing zeros.
+func (m ExchangeRate) String() string {
+	text := m.rat().FloatString(rateDigits)
+	return strings.TrimSuffix(strings.TrimRight(text, "0"), ".")
+}
+
+func (m ExchangeRate) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts numbers and quoted numbers.
+func (m *ExchangeRate) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	value, err := ParseExchangeRate(strings.Trim(string(data), "\""))
+	if err != nil {
+		return err
+	}
+	*m = value
+	return nil
+}
+
+// RatesTable is an offline CurrencyConverter.
+// Rates missing from the table are derived from the inverse rate, or crossed through a third currency.
+type RatesTable struct {
+	rates map[string]map[string]*Conversion
+}
+
+func NewRatesTable() *RatesTable {
+	return &RatesTable{rates: make(map[string]map[string]*Conversion)}
+}
+
+func ReadRatesFile(fileName string) (*RatesTable, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return LoadRates(file)
+}
+
+// LoadRates reads a CSV file with a header row naming the ratesColumns,
+// dates are formatted 2006-01-02.
+func LoadRates(input io.Reader) (*RatesTable, error) {
+	records := csv.NewReader(bufio.NewReader(input))
+	header, err := records.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int)
+	for index, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = index
+	}
+	for _, name := range ratesColumns {
+		if _, exists := columns[name]; !exists {
+			return nil, fmt.Errorf("Missing rates column %s", name)
+		}
+	}
+
+	results := NewRatesTable()
+	for {
+		record, err := records.Read()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		get := func(name string) string {
+			return strings.TrimSpace(record[columns[name]])
+		}
+		date, err := time.Parse(ratesDateFormat, get("date"))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid rate date %s: %s", get("date"), err)
+		}
+		rate, err := ParseExchangeRate(get("rate"))
+		if err != nil {
+			return nil, err
+		}
+		if err := results.Add(&Conversion{get("from"), get("to"), rate, date}); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Add keeps the most recent rate of each currency pair.
+func (m *RatesTable) Add(conversion *Conversion) error {
+	if conversion.Rate.Sign() <= 0 {
+		return fmt.Errorf("Invalid rate %s for %s/%s", conversion.Rate, conversion.From, conversion.To)
+	}
+	from := strings.ToUpper(conversion.From)
+	to := strings.ToUpper(conversion.To)
+	if from == to {
+		return fmt.Errorf("Invalid rate from %s to itself", from)
+	}
+	rates, exists := m.rates[from]
+	if !exists {
+		rates = make(map[string]*Conversion)
+		m.rates[from] = rates
+	}
+	if previous, exists := rates[to]; !exists || conversion.Date.After(previous.Date) {
+		rates[to] = &Conversion{from, to, conversion.Rate, conversion.Date}
+	}
+	return nil
+}
+
+func (m *RatesTable) Rate(from string, to string) (*Conversion, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+	if from == to {
+		return &Conversion{From: from, To: to, Rate: NewExchangeRate(1)}, nil
+	}
+	if conversion, exists := m.findRate(from, to); exists {
+		return conversion, nil
+	}
+	for _, via := range m.currencies() {
+		first, exists := m.findRate(from, via)
+		if !exists {
+			continue
+		}
+		second, exists := m.findRate(via, to)
+		if !exists {
+			continue
+		}
+		date := first.Date
+		if second.Date.Before(date) {
+			date = second.Date
+		}
+		return &Conversion{from, to, first.Rate.Mul(second.Rate), date}, nil
+	}
+	return nil, fmt.Errorf("No rate from %s to %s", from, to)
+}
+
+// findRate looks for the direct rate, then for the inverse one.
+func (m *RatesTable) findRate(from string, to string) (*Conversion, bool) {
+	if conversion, exists := m.rates[from][to]; exists {
+		return conversion, true
+	}
+	if conversion, exists := m.rates[to][from]; exists {
+		// Add rejects zero rates, the inverse always exists.
+		rate, err := conversion.Rate.Inverse()
+		if err != nil {
+			return nil, false
+		}
+		return &Conversion{from, to, rate, conversion.Date}, true
+	}
+	return nil, false
+}
+
+// currencies are sorted, so that cross rates do not depend on map ordering.
+func (m *RatesTable) currencies() []string {
+	known := make(map[string]bool)
+	for from, rates := range m.rates {
+		known[from] = true
+		for to := range rates {
+			known[to] = true
+		}
+	}
+	results := make([]string, 0, len(known))
+	for code := range known {
+		results = append(results, code)
+	}
+	sort.Strings(results)
+	return results
+}
+
+func findCurrencyOrCode(code string) *Currency {
+	if currency, exists := FindCurrency(code); exists {
+		return currency
+	}
+	return &Currency{Code: strings.ToUpper(code)}
+}
+
+// Convert returns copies of the itineraries priced in the target currency.
+func (m Itineraries) Convert(converter CurrencyConverter, to string) (Itineraries, error) {
+	currency := findCurrencyOrCode(to)
+	results := make(Itineraries, len(m))
+	for index, itinerary := range m {
+		if itinerary.Currency == nil {
+			return nil, fmt.Errorf("Unknown currency for itinerary %s", itinerary.OutboundLeg.Display())
+		}
+		conversion, err := converter.Rate(itinerary.Currency.Code, currency.Code)
+		if err != nil {
+			return nil, err
+		}
+		converted := *itinerary
+		converted.PricingOptions = make(PricingOptions, len(itinerary.PricingOptions))
+		for optionIndex, option := range itinerary.PricingOptions {
+			convertedOption := *option
+			convertedOption.Price = conversion.Rate.Apply(option.Price)
+			converted.PricingOptions[optionIndex] = &convertedOption
+		}
+		converted.Currency = currency
+		converted.Conversion = conversion
+		results[index] = &converted
+	}
+	return results, nil
+}
+
+// Convert returns copies of the quotes priced in the target currency.
+func (m FullQuotes) Convert(converter CurrencyConverter, to string) (FullQuotes, error) {
+	currency := findCurrencyOrCode(to)
+	results := make(FullQuotes, len(m))
+	for index, quote := range m {
+		if quote.Currency == nil {
+			return nil, fmt.Errorf("Unknown currency for quote to %s", quote.Destination.Name)
+		}
+		conversion, err := converter.Rate(quote.Currency.Code, currency.Code)
+		if err != nil {
+			return nil, err
+		}
+		quote.Quote.MinPrice = conversion.Rate.Apply(quote.Quote.MinPrice)
+		quote.Currency = currency
+		quote.Conversion = conversion
+		results[index] = quote
+	}
+	return results, nil
+}
+
+// RankItineraries merges the results of searches in several currencies,
+// cheapest first in the target currency.
+func RankItineraries(converter CurrencyConverter, to string, searches ...Itineraries) (Itineraries, error) {
+	results := make(Itineraries, 0)
+	for _, itineraries := range searches {
+		converted, err := itineraries.Convert(converter, to)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, converted...)
+	}
+	sort.Stable(results)
+	return results, nil
+}
+
+func RankFullQuotes(converter CurrencyConverter, to string, searches ...FullQuotes) (FullQuotes, error) {
+	results := make(FullQuotes, 0)
+	for _, quotes := range searches {
+		converted, err := quotes.Convert(converter, to)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, converted...)
+	}
+	sort.Stable(results)
+	return results, nil
+}
@@ -0,0 +1,107 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+const RatesLocation = TestDataBase + "rates.csv"
+
+func GetTestRates() *RatesTable {
+	rates, err := ReadRatesFile(RatesLocation)
+	if err != nil {
+		panic(err)
+	}
+	return rates
+}
+
+func TestRatesTable(t *testing.T) {
+	rates := GetTestRates()
+
+	direct, err := rates.Rate("gbp", "EUR")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.1802", direct.Rate.String())
+	assert.Equal(t, time.Date(2016, 8, 1, 0, 0, 0, 0, time.UTC), direct.Date)
+
+	inverse, err := rates.Rate("EUR", "GBP")
+	assert.Nil(t, err)
+	assert.Equal(t, "0.8473140146", inverse.Rate.String())
+
+	cross, err := rates.Rate("GBP", "USD")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.3176933", cross.Rate.String())
+
+	same, err := rates.Rate("GBP", "GBP")
+	assert.Nil(t, err)
+	assert.Equal(t, "1", same.Rate.String())
+
+	_, err = rates.Rate("GBP", "CHF")
+	assert.NotNil(t, err)
+}
+
+func TestHighValueRates(t *testing.T) {
+	rates, err := LoadRates(strings.NewReader("date,from,to,rate\n2016-08-01,GBP,VND,29000\n2016-08-01,EUR,GBP,0.8473\n"))
+	assert.Nil(t, err)
+
+	inverse, err := rates.Rate("VND", "GBP")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, inverse.Rate.Sign())
+	assert.Equal(t, NewDecimal(100), inverse.Rate.Apply(NewDecimal(2900000)))
+	assert.Equal(t, ParseDecimalOP("0.0345"), inverse.Rate.Apply(NewDecimal(1000)))
+
+	cross, err := rates.Rate("VND", "EUR")
+	assert.Nil(t, err)
+	assert.Equal(t, ParseDecimalOP("118.022"), cross.Rate.Apply(NewDecimal(2900000)))
+	assert.Equal(t, MaxDecimal, cross.Rate.Apply(MaxDecimal))
+
+	_, err = ExchangeRate{}.Inverse()
+	assert.NotNil(t, err)
+}
+
+func TestLoadRatesErrors(t *testing.T) {
+	_, err := LoadRates(strings.NewReader("date,from,to\n"))
+	assert.NotNil(t, err)
+	_, err = LoadRates(strings.NewReader("date,from,to,rate\n2016-08-01,GBP,EUR,-1\n"))
+	assert.NotNil(t, err)
+	_, err = LoadRates(strings.NewReader("date,from,to,rate\n01/08/2016,GBP,EUR,1.2\n"))
+	assert.NotNil(t, err)
+}
+
+func TestConvertItineraries(t *testing.T) {
+	data, err := ReadLiveReply(GetTestLiveReply())
+	if err != nil {
+		panic(err)
+	}
+	original := data.Itineraries[:3]
+	converted, err := original.Convert(GetTestRates(), "EUR")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(converted))
+	assert.Equal(t, "EUR", converted[0].Currency.Code)
+	assert.Equal(t, "GBP", original[0].Currency.Code)
+	assert.Equal(t, original[0].GetPrice().Mul(ParseDecimalOP("1.1802")), converted[0].GetPrice())
+	assert.Equal(t, "GBP", converted[0].Conversion.From)
+	assert.Nil(t, original[0].Conversion)
+
+	ranked, err := RankItineraries(GetTestRates(), "EUR", original, converted)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(ranked))
+	for index := 1; index < len(ranked); index++ {
+		assert.True(t, ranked[index-1].GetPrice() <= ranked[index].GetPrice())
+	}
+}
+
+func TestConvertFullQuotes(t *testing.T) {
+	reply := ParseBrowseRoutesReplyJson(ReadOrPanic(AnywhereLocationJson))
+	quotes := reply.GetBestQuotes()
+	converted, err := quotes.Convert(GetTestRates(), "USD")
+	assert.Nil(t, err)
+	rate, _ := GetTestRates().Rate("GBP", "USD")
+	assert.Equal(t, rate.Rate.Apply(quotes[0].Quote.MinPrice), converted[0].Quote.MinPrice)
+	assert.Equal(t, "USD", converted[0].Currency.Code)
+	assert.Equal(t, "$", converted[0].Currency.Symbol)
+
+	_, err = quotes.Convert(GetTestRates(), "CHF")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,236 @@
+package sklib
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	decimalDigits = 4
+	decimalScale  = 10000
+	// MaxDecimal stands for "no price", like math.MaxFloat64 did.
+	// Rounding, multiplying and converting leave it unchanged rather than overflow.
+	MaxDecimal = Decimal(math.MaxInt64)
+)
+
+// Decimal is a fixed-point amount with 4 decimal digits, so prices add up exactly.
+type Decimal int64
+
+// ParseDecimal accepts integer and decimal numbers, as the API sends both.
+// Digits beyond the 4th decimal are rounded half away from zero.
+func ParseDecimal(input string) (Decimal, error) {
+	input = strings.TrimSpace(input)
+	if strings.ContainsAny(input, "eE") {
+		value, err := strconv.ParseFloat(input, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid decimal %s", input)
+		}
+		return NewDecimalFromFloat(value), nil
+	}
+	negative := strings.HasPrefix(input, "-")
+	digits := strings.TrimPrefix(strings.TrimPrefix(input, "-"), "+")
+	integer, fraction := digits, ""
+	if index := strings.IndexByte(digits, '.'); index >= 0 {
+		integer, fraction = digits[:index], digits[index+1:]
+	}
+	if len(integer) == 0 && len(fraction) == 0 {
+		return 0, fmt.Errorf("Invalid decimal %s", input)
+	}
+	roundUp := false
+	if len(fraction) > decimalDigits {
+		if !isDigits(fraction) {
+			return 0, fmt.Errorf("Invalid decimal %s", input)
+		}
+		roundUp = fraction[decimalDigits] >= '5'
+		fraction = fraction[:decimalDigits]
+	}
+	fraction += strings.Repeat("0", decimalDigits-len(fraction))
+	if !isDigits(integer) || !isDigits(fraction) {
+		return 0, fmt.Errorf("Invalid decimal %s", input)
+	}
+	value, err := strconv.ParseInt(integer+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid decimal %s: %s", input, err)
+	}
+	if roundUp {
+		value++
+	}
+	if negative {
+		value = -value
+	}
+	return Decimal(value), nil
+}
+
+func ParseDecimalOP(input string) Decimal {
+	result, err := ParseDecimal(input)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func isDigits(input string) bool {
+	for _, c := range input {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func NewDecimal(units int64) Decimal {
+	return Decimal(units * decimalScale)
+}
+
+func NewDecimalFromFloat(value float64) Decimal {
+	return Decimal(math.Round(value * decimalScale))
+}
+
+func (m Decimal) Float64() float64 {
+	return float64(m) / decimalScale
+}
+
+// String drops trailing zeros: 326, 67.06.
+func (m Decimal) String() string {
+	return strings.TrimSuffix(strings.TrimRight(m.StringFixed(decimalDigits), "0"), ".")
+}
+
+// StringFixed rounds to the given number of decimal digits, at most 4.
+func (m Decimal) StringFixed(digits int) string {
+	if digits > decimalDigits {
+		digits = decimalDigits
+	}
+	if digits < 0 {
+		digits = 0
+	}
+	value := int64(m.RoundDigits(digits))
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	integer := strconv.FormatInt(value/decimalScale, 10)
+	if digits == 0 {
+		return sign + integer
+	}
+	fraction := fmt.Sprintf("%04d", value%decimalScale)[:digits]
+	return sign + integer + "." + fraction
+}
+
+// RoundDigits rounds half away from zero to the given number of decimal digits.
+func (m Decimal) RoundDigits(digits int) Decimal {
+	if digits >= decimalDigits {
+		return m
+	}
+	unit := int64(math.Pow10(decimalDigits - digits))
+	return roundTo(m, unit)
+}
+
+// Round rounds to a multiple of the currency RoundingCoefficient, in whole units.
+// A coefficient of 0 leaves the amount unchanged.
+func (m Decimal) Round(coefficient int) Decimal {
+	if coefficient <= 0 {
+		return m
+	}
+	return roundTo(m, int64(coefficient)*decimalScale)
+}
+
+func roundTo(value Decimal, unit int64) Decimal {
+	if value == MaxDecimal {
+		return value
+	}
+	units := int64(value)
+	remainder := units % unit
+	units -= remainder
+	if remainder*2 >= unit {
+		units += unit
+	} else if remainder*2 <= -unit {
+		units -= unit
+	}
+	return Decimal(units)
+}
+
+// Mul multiplies two decimals, rounding the result half away from zero.
+func (m Decimal) Mul(other Decimal) Decimal {
+	if m == MaxDecimal {
+		return m
+	}
+	product := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(int64(other)))
+	scale := big.NewInt(decimalScale)
+	quotient, remainder := new(big.Int).QuoRem(product, scale, new(big.Int))
+	remainder.Abs(remainder).Mul(remainder, big.NewInt(2))
+	if remainder.Cmp(scale) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(product.Sign())))
+	}
+	return Decimal(quotient.Int64())
+}
+
+// Div divides two decimals, rounding the result half away from zero.
+func (m Decimal) Div(other Decimal) (Decimal, error) {
+	if other == 0 {
+		return 0, fmt.Errorf("Division of %s by zero", m)
+	}
+	numerator := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(decimalScale))
+	return divideRounded(numerator, big.NewInt(int64(other))), nil
+}
+
+// divideRounded rounds the quotient half away from zero, the divisor cannot be 0.
+func divideRounded(numerator *big.Int, divisor *big.Int) Decimal {
+	quotient, remainder := new(big.Int).QuoRem(numerator, divisor, new(big.Int))
+	remainder.Abs(remainder).Mul(remainder, big.NewInt(2))
+	if remainder.Cmp(new(big.Int).Abs(divisor)) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(numerator.Sign()*divisor.Sign())))
+	}
+	return Decimal(quotient.Int64())
+}
+
+// MulInt is the total of count times the amount, for multi-passenger prices.
+func (m Decimal) MulInt(count int) Decimal {
+	if m == MaxDecimal {
+		return m
+	}
+	return m * Decimal(count)
+}
+
+func Sum(values ...Decimal) Decimal {
+	var result Decimal
+	for _, value := range values {
+		result += value
+	}
+	return result
+}
+
+// UnmarshalJSON accepts numbers and quoted numbers.
+func (m *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	value, err := ParseDecimal(strings.Trim(string(data), "\""))
+	if err != nil {
+		return err
+	}
+	*m = value
+	return nil
+}
+
+func (m Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText is used for XML replies, empty elements read as 0.
+func (m *Decimal) UnmarshalText(data []byte) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		*m = 0
+		return nil
+	}
+	value, err := ParseDecimal(string(data))
+	if err != nil {
+		return err
+	}
+	*m = value
+	return nil
+}
@@ -0,0 +1,74 @@
+package sklib
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	assert.Equal(t, Decimal(3260000), ParseDecimalOP("326"))
+	assert.Equal(t, Decimal(670600), ParseDecimalOP("67.06"))
+	assert.Equal(t, Decimal(-5000), ParseDecimalOP("-0.5"))
+	assert.Equal(t, Decimal(12346), ParseDecimalOP("1.23456"))
+	assert.Equal(t, NewDecimal(1200), ParseDecimalOP("1.2e3"))
+	for _, input := range []string{"", ".", "12a", "1.2.3", "--1"} {
+		_, err := ParseDecimal(input)
+		assert.NotNil(t, err, input)
+	}
+}
+
+func TestDecimalString(t *testing.T) {
+	assert.Equal(t, "326", NewDecimal(326).String())
+	assert.Equal(t, "67.06", ParseDecimalOP("67.06").String())
+	assert.Equal(t, "67.1", ParseDecimalOP("67.06").StringFixed(1))
+	assert.Equal(t, "-2.50", ParseDecimalOP("-2.5").StringFixed(2))
+	assert.Equal(t, "3", ParseDecimalOP("2.5").StringFixed(0))
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	assert.Equal(t, ParseDecimalOP("0.3"), Sum(ParseDecimalOP("0.1"), ParseDecimalOP("0.2")))
+	assert.Equal(t, ParseDecimalOP("201.18"), ParseDecimalOP("67.06").MulInt(3))
+	assert.Equal(t, ParseDecimalOP("80.472"), ParseDecimalOP("67.06").Mul(ParseDecimalOP("1.2")))
+	assert.Equal(t, ParseDecimalOP("-0.0001"), ParseDecimalOP("-0.0005").Mul(ParseDecimalOP("0.1")))
+	assert.Equal(t, NewDecimal(1235), NewDecimal(1233).Round(5))
+	assert.Equal(t, NewDecimal(1230), NewDecimal(1232).Round(5))
+	assert.Equal(t, NewDecimal(-100), ParseDecimalOP("-75").Round(50))
+	assert.Equal(t, NewDecimal(1233), NewDecimal(1233).Round(0))
+}
+
+func TestMaxDecimal(t *testing.T) {
+	assert.Equal(t, MaxDecimal, MaxDecimal.Round(1))
+	assert.Equal(t, MaxDecimal, MaxDecimal.RoundDigits(2))
+	assert.Equal(t, MaxDecimal, MaxDecimal.Mul(ParseDecimalOP("1.2")))
+	assert.Equal(t, MaxDecimal, MaxDecimal.MulInt(2))
+	assert.Equal(t, MaxDecimal, PricingOptions{}.GetPrice())
+
+	rounded := Currency{Code: "XXX", Symbol: "X", DecimalSeparator: ".", RoundingCoefficient: 1}
+	assert.NotContains(t, rounded.Format(MaxDecimal), "-")
+}
+
+func TestDecimalEncoding(t *testing.T) {
+	var value struct{ Price Decimal }
+	assert.Nil(t, json.Unmarshal([]byte(`{"Price": 67.06}`), &value))
+	assert.Equal(t, ParseDecimalOP("67.06"), value.Price)
+	assert.Nil(t, json.Unmarshal([]byte(`{"Price": "12"}`), &value))
+	assert.Equal(t, NewDecimal(12), value.Price)
+	assert.NotNil(t, json.Unmarshal([]byte(`{"Price": "abc"}`), &value))
+
+	data, err := json.Marshal(value)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"Price":12}`, string(data))
+
+	assert.Nil(t, xml.Unmarshal([]byte(`<Quote><Price>70.12</Price></Quote>`), &value))
+	assert.Equal(t, ParseDecimalOP("70.12"), value.Price)
+}
+
+func TestRouteFractionalPrice(t *testing.T) {
+	route := RouteDto{Price: json.Number("56.5")}
+	assert.True(t, route.Valid())
+	assert.Equal(t, ParseDecimalOP("56.5"), route.GetPrice())
+	route.Price = ""
+	assert.False(t, route.Valid())
+}
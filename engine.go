@@ -6,6 +6,8 @@ import (
 	"math/rand"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/boltdb/bolt"
@@ -16,8 +18,20 @@ type RequestEngine interface {
 	PostAndPoll(url string, form url.Values) (payload []byte, err error)
 }
 
+// PollCallback receives the payload of every poll round of a live session, the last one included.
+type PollCallback func(payload []byte)
+
+// ProgressEngine is implemented by engines able to report each poll round of a live session.
+type ProgressEngine interface {
+	PostAndPollProgress(url string, form url.Values, progress PollCallback) (payload []byte, err error)
+}
+
+// LiveEngine sends the requests to BaseURL instead of the partners API when set,
+// and polls live sessions following Poll.
 type LiveEngine struct {
-	Key string
+	Key     string
+	BaseURL string
+	Poll    PollPolicy
 }
 
 type CachedEngine struct {
@@ -29,6 +43,15 @@ type SlowEngine struct {
 	Engine RequestEngine
 }
 
+// ThrottledEngine spaces the requests it forwards by at least Interval,
+// under a CachedEngine so that cached replies are not throttled.
+type ThrottledEngine struct {
+	Engine   RequestEngine
+	Interval time.Duration
+	lock     sync.Mutex
+	next     time.Time
+}
+
 func (m *SlowEngine) Get(url string) ([]byte, error) {
 	m.Wait()
 	return m.Engine.Get(url)
@@ -44,6 +67,55 @@ func (m *SlowEngine) PostAndPoll(url string, form url.Values) ([]byte, error) {
 	return m.Engine.PostAndPoll(url, form)
 }
 
+func (m *SlowEngine) PostAndPollProgress(url string, form url.Values, progress PollCallback) ([]byte, error) {
+	m.Wait()
+	return PostAndPollProgress(m.Engine, url, form, progress)
+}
+
+func (m *ThrottledEngine) Get(url string) ([]byte, error) {
+	m.Wait()
+	return m.Engine.Get(url)
+}
+
+func (m *ThrottledEngine) PostAndPoll(url string, form url.Values) ([]byte, error) {
+	m.Wait()
+	return m.Engine.PostAndPoll(url, form)
+}
+
+func (m *ThrottledEngine) PostAndPollProgress(url string, form url.Values, progress PollCallback) ([]byte, error) {
+	m.Wait()
+	return PostAndPollProgress(m.Engine, url, form, progress)
+}
+
+// Wait books the next slot, so concurrent requests queue up rather than all waking together.
+func (m *ThrottledEngine) Wait() {
+	m.lock.Lock()
+	now := time.Now()
+	wait := m.next.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	m.next = now.Add(wait + m.Interval)
+	m.lock.Unlock()
+	time.Sleep(wait)
+}
+
+// PostAndPollProgress only reports the final payload of engines that are not ProgressEngines.
+// A nil progress reports nothing.
+func PostAndPollProgress(engine RequestEngine, url string, form url.Values, progress PollCallback) ([]byte, error) {
+	if progressEngine, ok := engine.(ProgressEngine); ok {
+		return progressEngine.PostAndPollProgress(url, form, progress)
+	}
+	payload, err := engine.PostAndPoll(url, form)
+	if err != nil {
+		return nil, err
+	}
+	if progress != nil {
+		progress(payload)
+	}
+	return payload, nil
+}
+
 func (m *CachedEngine) PostAndPoll(url string, form url.Values) ([]byte, error) {
 	cacheURL := url + "?" + form.Encode()
 	if cache := m.Cache.Get(cacheURL); cache != nil {
@@ -56,6 +128,22 @@ func (m *CachedEngine) PostAndPoll(url string, form url.Values) ([]byte, error)
 	return payload, m.Cache.Set(cacheURL, payload)
 }
 
+// PostAndPollProgress reports a cached session as a single round.
+func (m *CachedEngine) PostAndPollProgress(url string, form url.Values, progress PollCallback) ([]byte, error) {
+	cacheURL := url + "?" + form.Encode()
+	if cache := m.Cache.Get(cacheURL); cache != nil {
+		if progress != nil {
+			progress(cache)
+		}
+		return cache, nil
+	}
+	payload, err := PostAndPollProgress(m.Engine, url, form, progress)
+	if err != nil {
+		return nil, err
+	}
+	return payload, m.Cache.Set(cacheURL, payload)
+}
+
 func (m *CachedEngine) Get(url string) ([]byte, error) {
 
 	cache := m.Cache.Get(url)
@@ -70,27 +158,31 @@ func (m *CachedEngine) Get(url string) ([]byte, error) {
 }
 
 func (m *LiveEngine) PostAndPoll(url string, form url.Values) ([]byte, error) {
+	return m.PostAndPollProgress(url, form, nil)
+}
+
+func (m *LiveEngine) PostAndPollProgress(url string, form url.Values, progress PollCallback) ([]byte, error) {
 	form.Set("apiKey", m.Key)
 
-	resp, err := http.PostForm(url, form)
+	resp, err := http.PostForm(m.rebase(url), form)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	fmt.Println("response Status:", resp.Status)
-	fmt.Println("response Headers:", resp.Header)
+	fmt.Fprintln(Progress, "response Status:", resp.Status)
+	fmt.Fprintln(Progress, "response Headers:", resp.Header)
 	location := resp.Header.Get(locationKey)
 	body, _ := ioutil.ReadAll(resp.Body)
-	fmt.Println("response Body:", len(body))
-	fmt.Println(location)
-	fullUrl := location + formatKey(m.Key)
-	return Poll(fullUrl)
+	fmt.Fprintln(Progress, "response Body:", len(body))
+	fmt.Fprintln(Progress, location)
+	fullUrl := formatKey(location, m.Key)
+	return m.Poll.Poll(fullUrl, progress)
 }
 
 func (m *LiveEngine) Get(url string) ([]byte, error) {
 
-	fullUrl := url + formatKey(m.Key)
+	fullUrl := formatKey(m.rebase(url), m.Key)
 	resp, err := http.Get(fullUrl)
 	if err != nil {
 		return nil, err
@@ -100,6 +192,14 @@ func (m *LiveEngine) Get(url string) ([]byte, error) {
 
 }
 
+// rebase swaps the partners API host for BaseURL, leaving other URLs alone.
+func (m *LiveEngine) rebase(url string) string {
+	if len(m.BaseURL) == 0 || !strings.HasPrefix(url, apiBaseURL) {
+		return url
+	}
+	return strings.TrimSuffix(m.BaseURL, "/") + strings.TrimPrefix(url, apiBaseURL)
+}
+
 func CreateEngine(key string, noCache bool) (RequestEngine, func() error) {
 	engine := &LiveEngine{Key: key}
 	db := CreateDB()
@@ -113,3 +213,14 @@ func CreateEngine(key string, noCache bool) (RequestEngine, func() error) {
 func CreateCache(db *bolt.DB) *BoltStore {
 	return &BoltStore{db, bucketName}
 }
+
+// CreateExpiringCache keeps its entries apart from CreateCache's, which have no creation time.
+func CreateExpiringCache(db *bolt.DB, ttl time.Duration) *ExpiringStore {
+	return &ExpiringStore{Store: &BoltStore{db, expiringBucketName}, TTL: ttl}
+}
+
+// CreateCacheBuckets returns the buckets of both CreateCache and CreateExpiringCache,
+// to count or clear the whole cache whatever the TTL.
+func CreateCacheBuckets(db *bolt.DB) []*BoltStore {
+	return []*BoltStore{CreateCache(db), {db, expiringBucketName}}
+}
@@ -0,0 +1,136 @@
+package sklib
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	maxSuggestions    = 5
+	maxSuggestionCost = 2
+)
+
+// LocalisationData is the reference data a Localisation is checked against.
+type LocalisationData struct {
+	Locales    []LocaleDto
+	Currencies []CurrencyDto
+	Countries  []CountryDto
+}
+
+type LocalisationValidator struct {
+	countries  []string
+	currencies []string
+	locales    []string
+}
+
+// LocalisationError reports an unknown Localisation field, with the closest known codes.
+type LocalisationError struct {
+	Field       string
+	Value       string
+	Suggestions []string
+}
+
+// countryAliases are market codes the API accepts but does not list,
+// the reference data uses UK where the searches use the ISO code GB.
+var countryAliases = map[string]string{"GB": "UK"}
+
+var (
+	defaultLocalisationValidator     *LocalisationValidator
+	defaultLocalisationValidatorLock sync.Mutex
+)
+
+func NewLocalisationValidator(data LocalisationData) *LocalisationValidator {
+	result := &LocalisationValidator{
+		countries:  make([]string, len(data.Countries)),
+		currencies: make([]string, len(data.Currencies)),
+		locales:    make([]string, len(data.Locales))}
+	for index, country := range data.Countries {
+		result.countries[index] = country.Code
+	}
+	for alias, code := range countryAliases {
+		if validateCode("Country", code, result.countries) == nil {
+			result.countries = append(result.countries, alias)
+		}
+	}
+	for index, currency := range data.Currencies {
+		result.currencies[index] = currency.Code
+	}
+	for index, locale := range data.Locales {
+		result.locales[index] = locale.Code
+	}
+	return result
+}
+
+// DefaultLocalisationValidator checks against the embedded snapshot, unless replaced.
+func DefaultLocalisationValidator() *LocalisationValidator {
+	defaultLocalisationValidatorLock.Lock()
+	defer defaultLocalisationValidatorLock.Unlock()
+	if defaultLocalisationValidator == nil {
+		defaultLocalisationValidator = NewLocalisationValidator(localisationSnapshot)
+	}
+	return defaultLocalisationValidator
+}
+
+func SetDefaultLocalisationValidator(validator *LocalisationValidator) {
+	defaultLocalisationValidatorLock.Lock()
+	defer defaultLocalisationValidatorLock.Unlock()
+	defaultLocalisationValidator = validator
+}
+
+func ValidateLocalisation(localisation Localisation) error {
+	return DefaultLocalisationValidator().Validate(localisation)
+}
+
+func (m *LocalisationValidator) Validate(localisation Localisation) error {
+	if err := validateCode("Country", localisation.Country, m.countries); err != nil {
+		return err
+	}
+	if err := validateCode("Currency", localisation.Currency, m.currencies); err != nil {
+		return err
+	}
+	return validateCode("Language", localisation.Language, m.locales)
+}
+
+// validateCode ignores case, the API does too.
+func validateCode(field string, value string, codes []string) error {
+	for _, code := range codes {
+		if strings.EqualFold(code, value) {
+			return nil
+		}
+	}
+	return &LocalisationError{field, value, suggestCodes(value, codes)}
+}
+
+func suggestCodes(value string, codes []string) []string {
+	type candidate struct {
+		code string
+		cost int
+	}
+	candidates := make([]candidate, 0)
+	for _, code := range codes {
+		cost := editDistance(strings.ToUpper(value), strings.ToUpper(code))
+		if cost <= maxSuggestionCost {
+			candidates = append(candidates, candidate{code, cost})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].cost == candidates[j].cost {
+			return candidates[i].code < candidates[j].code
+		}
+		return candidates[i].cost < candidates[j].cost
+	})
+	results := make([]string, 0, maxSuggestions)
+	for index := 0; index < len(candidates) && index < maxSuggestions; index++ {
+		results = append(results, candidates[index].code)
+	}
+	return results
+}
+
+func (m *LocalisationError) Error() string {
+	if len(m.Suggestions) == 0 {
+		return fmt.Sprintf("Unknown %s %s", m.Field, m.Value)
+	}
+	return fmt.Sprintf("Unknown %s %s, did you mean %s?", m.Field, m.Value, strings.Join(m.Suggestions, ", "))
+}
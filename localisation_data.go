@@ -0,0 +1,441 @@
+package sklib
+
+// localisationSnapshot is the embedded copy of the locales, currencies and countries (en-GB) reference data.
+var localisationSnapshot = LocalisationData{
+	Locales: []LocaleDto{
+		{"ar-AE", "العربية (الإمارات العربية المتحدة)"},
+		{"az-AZ", "Azərbaycan\u00adılı (Azərbaycan)"},
+		{"bg-BG", "български (България)"},
+		{"ca-ES", "català (català)"},
+		{"cs-CZ", "čeština (Česká\u00a0republika)"},
+		{"da-DK", "dansk (Danmark)"},
+		{"de-DE", "Deutsch (Deutschland)"},
+		{"el-GR", "Ελληνικά (Ελλάδα)"},
+		{"en-GB", "English (United Kingdom)"},
+		{"en-GG", "English (United Kingdom)"},
+		{"en-US", "English (United States)"},
+		{"es-ES", "Español (España)"},
+		{"es-MX", "Español (México)"},
+		{"et-EE", "eesti (Eesti)"},
+		{"fi-FI", "suomi (Suomi)"},
+		{"fr-FR", "français (France)"},
+		{"hr-HR", "hrvatski (Hrvatska)"},
+		{"hu-HU", "magyar (Magyarország)"},
+		{"id-ID", "Bahasa Indonesia (Indonesia)"},
+		{"it-IT", "italiano (Italia)"},
+		{"ja-JP", "日本語 (日本)"},
+		{"ko-KR", "한국어 (대한민국)"},
+		{"lt-LT", "lietuvių (Lietuva)"},
+		{"lv-LV", "latviešu (Latvija)"},
+		{"ms-MY", "Bahasa Melayu (Malaysia)"},
+		{"nb-NO", "norsk, bokmål (Norge)"},
+		{"nl-NL", "Nederlands (Nederland)"},
+		{"pl-PL", "polski (Polska)"},
+		{"pt-BR", "Português (Brasil)"},
+		{"pt-PT", "português (Portugal)"},
+		{"ro-RO", "română (România)"},
+		{"ru-RU", "русский (Россия)"},
+		{"sk-SK", "slovenčina (Slovenská republika)"},
+		{"sv-SE", "svenska (Sverige)"},
+		{"th-TH", "ไทย (ไทย)"},
+		{"tl-PH", "Filipino (Pilipinas)"},
+		{"tr-TR", "Türkçe (Türkiye)"},
+		{"uk-UA", "українська (Україна)"},
+		{"vi-VN", "Tiếng Việt (Việt Nam)"},
+		{"zh-CN", "简体中文"},
+		{"zh-HK", "中文(香港特別行政區)"},
+		{"zh-SG", "中文(新加坡)"},
+		{"zh-TW", "繁體中文"},
+	},
+	Currencies: []CurrencyDto{
+		{"AED", "د.إ.\u200f", ",", ".", true, true, 0, 2},
+		{"AFN", "AFN", ",", ".", true, false, 0, 2},
+		{"ALL", "Lek", ".", ",", false, false, 0, 2},
+		{"AMD", "դր.", ",", ".", false, true, 0, 2},
+		{"ANG", "NAf.", ".", ",", true, false, 0, 2},
+		{"AOA", "Kz", ",", ".", true, false, 0, 2},
+		{"ARS", "$", ".", ",", true, true, 0, 2},
+		{"AUD", "$", ",", ".", true, false, 0, 2},
+		{"AWG", "Afl.", ",", ".", true, false, 0, 2},
+		{"AZN", "₼", "\u00a0", ",", false, true, 0, 2},
+		{"BAM", "КМ", ".", ",", false, true, 0, 2},
+		{"BBD", "$", ",", ".", true, false, 0, 2},
+		{"BDT", "BDT", ",", ".", true, true, 0, 2},
+		{"BGN", "лв.", "\u00a0", ",", false, true, 0, 2},
+		{"BHD", "د.ب.\u200f", ",", ".", true, true, 0, 3},
+		{"BIF", "FBu", ",", ".", false, false, 0, 0},
+		{"BMD", "$", ",", ".", true, false, 0, 2},
+		{"BND", "$", ".", ",", true, false, 0, 2},
+		{"BOB", "Bs", ".", ",", true, true, 0, 2},
+		{"BRL", "R$", ".", ",", true, true, 0, 2},
+		{"BSD", "$", ",", ".", true, false, 0, 2},
+		{"BTN", "Nu.", ",", ".", true, true, 0, 2},
+		{"BWP", "P", ",", ".", true, false, 0, 2},
+		{"BYR", "р.", "\u00a0", ",", false, true, 0, 2},
+		{"BZD", "BZ$", ",", ".", true, false, 0, 2},
+		{"CAD", "$", ",", ".", true, false, 0, 2},
+		{"CDF", "FC", ",", ".", false, false, 0, 2},
+		{"CHF", "CHF", "'", ".", true, true, 0, 2},
+		{"CLP", "$", ".", ",", true, true, 0, 2},
+		{"CNY", "¥", ",", ".", true, false, 0, 2},
+		{"COP", "$", ".", ",", true, true, 0, 2},
+		{"CRC", "₡", ".", ",", true, false, 0, 2},
+		{"CUC", "CUC", ",", ".", true, false, 0, 2},
+		{"CUP", "$MN", ",", ".", true, false, 0, 2},
+		{"CVE", "$", ",", ".", true, false, 0, 2},
+		{"CZK", "Kč", "\u00a0", ",", false, true, 0, 2},
+		{"DJF", "Fdj", ",", ".", false, false, 0, 0},
+		{"DKK", "kr.", ".", ",", true, true, 0, 2},
+		{"DOP", "RD$", ",", ".", true, false, 0, 2},
+		{"DZD", "د.ج.\u200f", ",", ".", true, true, 0, 2},
+		{"EGP", "ج.م.\u200f", ",", ".", true, true, 0, 2},
+		{"ERN", "Nfk", ",", ".", false, false, 0, 2},
+		{"ETB", "ETB", ",", ".", true, false, 0, 2},
+		{"EUR", "€", "\u00a0", ",", false, true, 0, 2},
+		{"FJD", "$", ",", ".", true, false, 0, 2},
+		{"GBP", "£", ",", ".", true, false, 0, 2},
+		{"GEL", "₾", "\u00a0", ",", false, true, 0, 2},
+		{"GHS", "GH¢", ",", ".", true, false, 0, 2},
+		{"GIP", "£", ",", ".", true, false, 0, 2},
+		{"GMD", "D", ",", ".", false, false, 0, 2},
+		{"GNF", "FG", ",", ".", false, false, 0, 0},
+		{"GTQ", "Q", ",", ".", true, false, 0, 2},
+		{"GYD", "$", ",", ".", true, false, 0, 2},
+		{"HKD", "HK$", ",", ".", true, false, 0, 2},
+		{"HNL", "L.", ",", ".", true, true, 0, 2},
+		{"HRK", "kn", ".", ",", false, true, 0, 2},
+		{"HTG", "G", ",", ".", true, false, 0, 2},
+		{"HUF", "Ft", "\u00a0", ",", false, true, 0, 2},
+		{"IDR", "Rp", ".", ",", true, false, 0, 2},
+		{"ILS", "₪", ",", ".", true, true, 0, 2},
+		{"INR", "₹", ",", ".", true, false, 0, 2},
+		{"IQD", "د.ع.\u200f", ",", ".", true, true, 0, 2},
+		{"IRR", "ريال", ",", "/", true, true, 0, 2},
+		{"ISK", "kr.", ".", ",", false, true, 0, 0},
+		{"JMD", "J$", ",", ".", true, false, 0, 2},
+		{"JOD", "د.ا.\u200f", ",", ".", true, true, 0, 3},
+		{"JPY", "¥", ",", ".", true, false, 0, 0},
+		{"KES", "S", ",", ".", true, false, 0, 2},
+		{"KGS", "сом", "\u00a0", "-", false, true, 0, 2},
+		{"KHR", "KHR", ",", ".", false, false, 0, 2},
+		{"KMF", "CF", ",", ".", false, false, 0, 2},
+		{"KPW", "₩", ",", ".", true, false, 0, 0},
+		{"KRW", "₩", ",", ".", true, false, 0, 0},
+		{"KWD", "د.ك.\u200f", ",", ".", true, true, 0, 3},
+		{"KYD", "$", ",", ".", true, false, 0, 2},
+		{"KZT", "Т", "\u00a0", "-", true, false, 0, 2},
+		{"LAK", "₭", ",", ".", false, false, 0, 0},
+		{"LBP", "ل.ل.\u200f", ",", ".", true, true, 0, 2},
+		{"LKR", "Rp", ",", ".", true, true, 0, 2},
+		{"LRD", "$", ",", ".", true, false, 0, 2},
+		{"LSL", "M", ",", ".", false, false, 0, 2},
+		{"LYD", "د.ل.\u200f", ",", ".", true, false, 0, 3},
+		{"MAD", "د.م.\u200f", ",", ".", true, true, 0, 2},
+		{"MDL", "lei", ",", ".", false, true, 0, 2},
+		{"MGA", "Ar", ",", ".", true, false, 0, 0},
+		{"MKD", "ден.", ".", ",", false, true, 0, 2},
+		{"MMK", "K", ",", ".", true, false, 0, 2},
+		{"MNT", "₮", "\u00a0", ",", true, false, 0, 2},
+		{"MOP", "MOP$", ",", ".", true, false, 0, 2},
+		{"MRO", "UM", ",", ".", false, false, 0, 2},
+		{"MUR", "Rs", ",", ".", true, false, 0, 2},
+		{"MVR", "MVR", ",", ".", false, true, 0, 2},
+		{"MWK", "MK", ",", ".", true, false, 0, 2},
+		{"MXN", "$", ",", ".", true, false, 0, 2},
+		{"MYR", "RM", ",", ".", true, false, 0, 2},
+		{"MZN", "MT", ",", ".", true, false, 0, 2},
+		{"NAD", "$", ",", ".", true, false, 0, 2},
+		{"NGN", "₦", ",", ".", true, false, 0, 2},
+		{"NIO", "C$", ",", ".", true, true, 0, 2},
+		{"NOK", "kr", "\u00a0", ",", true, true, 0, 2},
+		{"NPR", "रु", ",", ".", true, false, 0, 2},
+		{"NZD", "$", ",", ".", true, false, 0, 2},
+		{"OMR", "ر.ع.\u200f", ",", ".", true, true, 0, 3},
+		{"PAB", "B/.", ",", ".", true, true, 0, 2},
+		{"PEN", "S/.", ".", ".", true, true, 0, 2},
+		{"PGK", "K", ",", ".", true, false, 0, 2},
+		{"PHP", "P", ",", ".", true, false, 0, 2},
+		{"PKR", "Rs", ",", ".", true, false, 0, 2},
+		{"PLN", "zł", "\u00a0", ",", false, true, 0, 2},
+		{"PYG", "Gs", ".", ",", true, true, 0, 2},
+		{"QAR", "ر.ق.\u200f", ",", ".", true, true, 0, 2},
+		{"RON", "lei", ".", ",", false, true, 0, 2},
+		{"RSD", "Дин.", ".", ",", false, true, 0, 2},
+		{"RUB", "p.", "\u00a0", ",", false, true, 0, 2},
+		{"RWF", "RWF", "\u00a0", ",", true, true, 0, 2},
+		{"SAR", "ر.س.\u200f", ",", ".", true, true, 0, 2},
+		{"SBD", "$", ",", ".", true, false, 0, 2},
+		{"SCR", "Rs", ",", ".", true, false, 0, 2},
+		{"SDG", "ج.س.\u200f", ",", ".", true, false, 0, 2},
+		{"SEK", "kr", ".", ",", false, true, 0, 0},
+		{"SGD", "$", ",", ".", true, false, 0, 2},
+		{"SHP", "£", ",", ".", true, false, 0, 2},
+		{"SLL", "Le", ",", ".", true, false, 0, 2},
+		{"SOS", "S", ",", ".", true, false, 0, 2},
+		{"SRD", "$", ",", ".", true, false, 0, 2},
+		{"STD", "Db", ",", ".", true, false, 0, 2},
+		{"SYP", "ل.س.\u200f", ",", ".", true, true, 0, 2},
+		{"SZL", "E", ",", ".", true, false, 0, 2},
+		{"THB", "฿", ",", ".", true, false, 0, 2},
+		{"TJS", "TJS", "\u00a0", ";", false, true, 0, 2},
+		{"TMT", "m", "\u00a0", ",", false, false, 0, 2},
+		{"TND", "د.ت.\u200f", ",", ".", true, true, 0, 3},
+		{"TOP", "T$", ",", ".", true, false, 0, 2},
+		{"TRY", "TL", ".", ",", false, true, 0, 2},
+		{"TTD", "TT$", ",", ".", true, false, 0, 2},
+		{"TWD", "NT$", ",", ".", true, false, 0, 2},
+		{"TZS", "TSh", ",", ".", true, false, 0, 2},
+		{"UAH", "грн.", "\u00a0", ",", false, false, 0, 2},
+		{"UGX", "USh", ",", ".", true, false, 0, 2},
+		{"USD", "$", ",", ".", true, false, 0, 2},
+		{"UYU", "$U", ".", ",", true, true, 0, 2},
+		{"UZS", "сўм", "\u00a0", ",", false, true, 0, 2},
+		{"VEF", "Bs. F.", ".", ",", true, true, 0, 2},
+		{"VND", "₫", ".", ",", false, true, 0, 1},
+		{"VUV", "VT", ",", ".", false, false, 0, 0},
+		{"WST", "WS$", ",", ".", true, false, 0, 2},
+		{"XAF", "F", ",", ".", false, false, 0, 2},
+		{"XCD", "$", ",", ".", true, false, 0, 2},
+		{"XOF", "F", "\u00a0", ",", false, false, 0, 2},
+		{"XPF", "F", ",", ".", false, false, 0, 2},
+		{"YER", "ر.ي.\u200f", ",", ".", true, true, 0, 2},
+		{"ZAR", "R", ",", ".", true, true, 0, 2},
+		{"ZMW", "ZK", ",", ".", true, false, 0, 2},
+	},
+	Countries: []CountryDto{
+		{"AD", "Andorra"},
+		{"AE", "United Arab Emirates"},
+		{"AF", "Afghanistan"},
+		{"AG", "Antigua and Barbuda"},
+		{"AI", "Anguilla"},
+		{"AL", "Albania"},
+		{"AM", "Armenia"},
+		{"AN", "Netherlands Antilles"},
+		{"AO", "Angola"},
+		{"AQ", "Antarctica"},
+		{"AR", "Argentina"},
+		{"AS", "American Samoa"},
+		{"AT", "Austria"},
+		{"AU", "Australia"},
+		{"AW", "Aruba"},
+		{"AZ", "Azerbaijan"},
+		{"BA", "Bosnia and Herzegovina"},
+		{"BB", "Barbados"},
+		{"BD", "Bangladesh"},
+		{"BE", "Belgium"},
+		{"BF", "Burkina Faso"},
+		{"BG", "Bulgaria"},
+		{"BH", "Bahrain"},
+		{"BI", "Burundi"},
+		{"BJ", "Benin"},
+		{"BL", "Saint Barthelemy"},
+		{"BM", "Bermuda"},
+		{"BN", "Brunei"},
+		{"BO", "Bolivia"},
+		{"BQ", "Caribbean Netherlands"},
+		{"BR", "Brazil"},
+		{"BS", "Bahamas"},
+		{"BT", "Bhutan"},
+		{"BW", "Botswana"},
+		{"BY", "Belarus"},
+		{"BZ", "Belize"},
+		{"CA", "Canada"},
+		{"CC", "Cocos (Keeling) Islands"},
+		{"CD", "DR Congo"},
+		{"CF", "Central African Republic"},
+		{"CG", "Congo"},
+		{"CH", "Switzerland"},
+		{"CI", "Ivory Coast"},
+		{"CK", "Cook Islands"},
+		{"CL", "Chile"},
+		{"CM", "Cameroon"},
+		{"CN", "China"},
+		{"CO", "Colombia"},
+		{"CR", "Costa Rica"},
+		{"CU", "Cuba"},
+		{"CV", "Cape Verde"},
+		{"CW", "Curacao"},
+		{"CX", "Christmas Island"},
+		{"CY", "Cyprus"},
+		{"CZ", "Czech Republic"},
+		{"DE", "Germany"},
+		{"DJ", "Djibouti"},
+		{"DK", "Denmark"},
+		{"DM", "Dominica"},
+		{"DO", "Dominican Republic"},
+		{"DZ", "Algeria"},
+		{"EC", "Ecuador"},
+		{"EE", "Estonia"},
+		{"EG", "Egypt"},
+		{"ER", "Eritrea"},
+		{"ES", "Spain"},
+		{"ET", "Ethiopia"},
+		{"FI", "Finland"},
+		{"FJ", "Fiji"},
+		{"FK", "Falkland Islands"},
+		{"FM", "Micronesia"},
+		{"FO", "Faroe Islands"},
+		{"FR", "France"},
+		{"GA", "Gabon"},
+		{"GD", "Grenada"},
+		{"GE", "Georgia"},
+		{"GF", "French Guiana"},
+		{"GG", "Guernsey"},
+		{"GH", "Ghana"},
+		{"GI", "Gibraltar"},
+		{"GL", "Greenland"},
+		{"GM", "Gambia"},
+		{"GN", "Guinea"},
+		{"GP", "Guadeloupe"},
+		{"GQ", "Equatorial Guinea"},
+		{"GR", "Greece"},
+		{"GS", "South Georgia & South Sandwich Islands"},
+		{"GT", "Guatemala"},
+		{"GU", "Guam"},
+		{"GW", "Guinea-Bissau"},
+		{"GY", "Guyana"},
+		{"HK", "Hong Kong"},
+		{"HN", "Honduras"},
+		{"HR", "Croatia"},
+		{"HT", "Haiti"},
+		{"HU", "Hungary"},
+		{"ID", "Indonesia"},
+		{"IE", "Ireland"},
+		{"IL", "Israel"},
+		{"IN", "India"},
+		{"IQ", "Iraq"},
+		{"IR", "Iran"},
+		{"IS", "Iceland"},
+		{"IT", "Italy"},
+		{"JM", "Jamaica"},
+		{"JO", "Jordan"},
+		{"JP", "Japan"},
+		{"KE", "Kenya"},
+		{"KG", "Kyrgyzstan"},
+		{"KH", "Cambodia"},
+		{"KI", "Kiribati"},
+		{"KM", "Comoros"},
+		{"KN", "Saint Kitts and Nevis"},
+		{"KO", "Kosovo"},
+		{"KP", "North Korea"},
+		{"KR", "South Korea"},
+		{"KW", "Kuwait"},
+		{"KY", "Cayman Islands"},
+		{"KZ", "Kazakhstan"},
+		{"LA", "Laos"},
+		{"LB", "Lebanon"},
+		{"LC", "Saint Lucia"},
+		{"LI", "Liechtenstein"},
+		{"LK", "Sri Lanka"},
+		{"LR", "Liberia"},
+		{"LS", "Lesotho"},
+		{"LT", "Lithuania"},
+		{"LU", "Luxembourg"},
+		{"LV", "Latvia"},
+		{"LY", "Libya"},
+		{"MA", "Morocco"},
+		{"MC", "Monaco"},
+		{"MD", "Moldova"},
+		{"ME", "Montenegro"},
+		{"MG", "Madagascar"},
+		{"MH", "Marshall Islands"},
+		{"MK", "Republic of Macedonia"},
+		{"ML", "Mali"},
+		{"MM", "Myanmar"},
+		{"MN", "Mongolia"},
+		{"MO", "Macau"},
+		{"MP", "Northern Mariana Islands"},
+		{"MQ", "Martinique"},
+		{"MR", "Mauritania"},
+		{"MS", "Montserrat"},
+		{"MT", "Malta"},
+		{"MU", "Mauritius"},
+		{"MV", "Maldives"},
+		{"MW", "Malawi"},
+		{"MX", "Mexico"},
+		{"MY", "Malaysia"},
+		{"MZ", "Mozambique"},
+		{"NA", "Namibia"},
+		{"NC", "New Caledonia"},
+		{"NE", "Niger"},
+		{"NG", "Nigeria"},
+		{"NI", "Nicaragua"},
+		{"NL", "Netherlands"},
+		{"NO", "Norway"},
+		{"NP", "Nepal"},
+		{"NR", "Nauru"},
+		{"NU", "Niue"},
+		{"NZ", "New Zealand"},
+		{"OM", "Oman"},
+		{"PA", "Panama"},
+		{"PE", "Peru"},
+		{"PF", "French Polynesia"},
+		{"PG", "Papua New Guinea"},
+		{"PH", "Philippines"},
+		{"PK", "Pakistan"},
+		{"PL", "Poland"},
+		{"PM", "St. Pierre and Miquelon"},
+		{"PR", "Puerto Rico"},
+		{"PT", "Portugal"},
+		{"PW", "Palau"},
+		{"PY", "Paraguay"},
+		{"QA", "Qatar"},
+		{"RE", "Reunion"},
+		{"RO", "Romania"},
+		{"RS", "Serbia"},
+		{"RU", "Russia"},
+		{"RW", "Rwanda"},
+		{"SA", "Saudi Arabia"},
+		{"SB", "Solomon Islands"},
+		{"SC", "Seychelles"},
+		{"SD", "Sudan"},
+		{"SE", "Sweden"},
+		{"SG", "Singapore"},
+		{"SI", "Slovenia"},
+		{"SK", "Slovakia"},
+		{"SL", "Sierra Leone"},
+		{"SN", "Senegal"},
+		{"SO", "Somalia"},
+		{"SR", "Suriname"},
+		{"SS", "South Sudan"},
+		{"ST", "Sao Tome and Principe"},
+		{"SV", "El Salvador"},
+		{"SX", "St Maarten"},
+		{"SY", "Syria"},
+		{"SZ", "Swaziland"},
+		{"TC", "Turks and Caicos Islands"},
+		{"TD", "Chad"},
+		{"TG", "Togo"},
+		{"TH", "Thailand"},
+		{"TJ", "Tajikistan"},
+		{"TL", "East Timor"},
+		{"TM", "Turkmenistan"},
+		{"TN", "Tunisia"},
+		{"TO", "Tonga"},
+		{"TR", "Turkey"},
+		{"TT", "Trinidad and Tobago"},
+		{"TV", "Tuvalu"},
+		{"TW", "Taiwan"},
+		{"TZ", "Tanzania"},
+		{"UA", "Ukraine"},
+		{"UG", "Uganda"},
+		{"UK", "United Kingdom"},
+		{"US", "United States"},
+		{"UY", "Uruguay"},
+		{"UZ", "Uzbekistan"},
+		{"VA", "Vatican City"},
+		{"VC", "Saint Vincent and the Grenadines"},
+		{"VE", "Venezuela"},
+		{"VG", "British Virgin Islands"},
+		{"VI", "US Virgin Islands"},
+		{"VN", "Vietnam"},
+		{"VU", "Vanuatu"},
+		{"WF", "Wallis and Futuna Islands"},
+		{"WS", "Samoa"},
+		{"YE", "Yemen"},
+		{"YT", "Mayotte"},
+		{"ZA", "South Africa"},
+		{"ZM", "Zambia"},
+		{"ZW", "Zimbabwe"},
+	},
+}
@@ -0,0 +1,58 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateLocalisation(t *testing.T) {
+	assert.Nil(t, ValidateLocalisation(Localisation{"GB", "GBP", "en-GB"}))
+	assert.Nil(t, ValidateLocalisation(Localisation{"gb", "gbp", "en-gb"}))
+
+	err := ValidateLocalisation(Localisation{"GB", "GPB", "en-GB"}).(*LocalisationError)
+	assert.Equal(t, "Currency", err.Field)
+	assert.Equal(t, "GPB", err.Value)
+	assert.Equal(t, "GBP", err.Suggestions[0])
+	assert.True(t, len(err.Suggestions) <= maxSuggestions)
+
+	err = ValidateLocalisation(Localisation{"GB", "GBP", "en-UK"}).(*LocalisationError)
+	assert.Equal(t, "Language", err.Field)
+	assert.Contains(t, err.Suggestions, "en-GB")
+
+	err = ValidateLocalisation(Localisation{"XYZXYZ", "GBP", "en-GB"}).(*LocalisationError)
+	assert.Equal(t, "Country", err.Field)
+	assert.Equal(t, 0, len(err.Suggestions))
+}
+
+func TestBrowseValidatesLocalisation(t *testing.T) {
+	engine := NewTestEngine(map[string]string{})
+	request := NewBrowseRouteRequest(Localisation{"GB", "GPB", "en-GB"}, "LON", "20160819", "20160821")
+	_, err := Browse(engine, request)
+	assert.IsType(t, &LocalisationError{}, err)
+
+	_, err = Search(engine, SearchRequest{
+		Localisation:  Localisation{"GB", "GPB", "en-GB"},
+		Origin:        "LON",
+		Destinations:  []string{"VIE"},
+		DepartureDate: "20161101"})
+	assert.IsType(t, &LocalisationError{}, err)
+	assert.Equal(t, 0, len(engine.Calls))
+}
+
+func TestFetchedLocalisationValidator(t *testing.T) {
+	api := &EngineSearchAPI{engine: GetTestReferenceEngine()}
+	data, err := api.FetchLocalisationData("en-GB")
+	if err != nil {
+		panic(err)
+	}
+	validator := NewLocalisationValidator(data)
+	assert.Nil(t, validator.Validate(Localisation{"FR", "EUR", "fr-FR"}))
+	assert.NotNil(t, validator.Validate(Localisation{"FR", "EUR", "fr-XX"}))
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("GBP", "GBP"))
+	assert.Equal(t, 1, editDistance("GPB", "GBP"))
+	assert.Equal(t, 1, editDistance("GB", "GBP"))
+	assert.Equal(t, 3, editDistance("", "GBP"))
+}
@@ -0,0 +1,177 @@
+package sklib
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	RankByTotal    = "total"
+	RankByMax      = "max"
+	RankByVariance = "variance"
+)
+
+// MeetingRequest looks for destinations every origin can fly to on the same dates.
+type MeetingRequest struct {
+	Localisation  Localisation
+	Origins       []string
+	DepartureDate string
+	ReturnDate    string
+	RankBy        string
+}
+
+// MeetingPoint is a destination reachable from every origin,
+// Quotes holds the best quote of each origin, in the order of the request origins.
+type MeetingPoint struct {
+	Destination PlaceDto
+	Quotes      FullQuotes
+}
+
+type MeetingPoints []*MeetingPoint
+
+// meetingPointSorter ranks MeetingPoints by a price score, lowest first.
+type meetingPointSorter struct {
+	points MeetingPoints
+	score  func(point *MeetingPoint) Decimal
+}
+
+func (m *MeetingRequest) GetRankBy() string {
+	if len(m.RankBy) == 0 {
+		return RankByTotal
+	}
+	return m.RankBy
+}
+
+func (m *MeetingRequest) Validate() error {
+	if len(m.Origins) < 2 {
+		return &ValidationError{"Origins", fmt.Sprintf("expected at least 2 origins, got %d", len(m.Origins))}
+	}
+	seen := make(map[string]bool)
+	for _, origin := range m.Origins {
+		if len(origin) == 0 {
+			return &ValidationError{"Origins", "empty origin"}
+		}
+		if seen[origin] {
+			return &ValidationError{"Origins", fmt.Sprintf("duplicate origin %s", origin)}
+		}
+		seen[origin] = true
+	}
+	switch m.GetRankBy() {
+	case RankByTotal, RankByMax, RankByVariance:
+	default:
+		return &ValidationError{"RankBy", fmt.Sprintf("unknown ranking %s", m.RankBy)}
+	}
+	return nil
+}
+
+// FindMeetingPoints runs an anywhere Browse per origin through the same engine,
+// and ranks the destinations found from every origin.
+func FindMeetingPoints(engine RequestEngine, request MeetingRequest) (MeetingPoints, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	byOrigin := make([]map[string]FullQuote, len(request.Origins))
+	for index, origin := range request.Origins {
+		fmt.Fprintln(Progress, "Searching from", origin)
+		browseRequest := NewBrowseRouteRequest(request.Localisation, origin, request.DepartureDate, request.ReturnDate)
+		quotes, err := Browse(engine, browseRequest)
+		if err != nil {
+			return nil, err
+		}
+		byOrigin[index] = bestQuoteByDestination(quotes)
+	}
+
+	results := make(MeetingPoints, 0)
+	for code, first := range byOrigin[0] {
+		point := &MeetingPoint{Destination: first.Destination, Quotes: FullQuotes{first}}
+		for _, quotes := range byOrigin[1:] {
+			quote, exists := quotes[code]
+			if !exists {
+				point = nil
+				break
+			}
+			point.Quotes = append(point.Quotes, quote)
+		}
+		if point != nil {
+			results = append(results, point)
+		}
+	}
+	results.Rank(request.GetRankBy())
+	return results, nil
+}
+
+func bestQuoteByDestination(quotes FullQuotes) map[string]FullQuote {
+	results := make(map[string]FullQuote)
+	for _, quote := range quotes {
+		code := quote.Destination.SkyscannerCode
+		if best, exists := results[code]; !exists || quote.Quote.MinPrice < best.Quote.MinPrice {
+			results[code] = quote
+		}
+	}
+	return results
+}
+
+func (m *MeetingPoint) GetTotal() Decimal {
+	prices := make([]Decimal, len(m.Quotes))
+	for index, quote := range m.Quotes {
+		prices[index] = quote.Quote.MinPrice
+	}
+	return Sum(prices...)
+}
+
+func (m *MeetingPoint) GetMax() Decimal {
+	var result Decimal
+	for _, quote := range m.Quotes {
+		if quote.Quote.MinPrice > result {
+			result = quote.Quote.MinPrice
+		}
+	}
+	return result
+}
+
+// GetVariance is the population variance of the prices, how unfair the trip is to the travellers.
+func (m *MeetingPoint) GetVariance() Decimal {
+	count := Decimal(len(m.Quotes))
+	if count == 0 {
+		return 0
+	}
+	mean := m.GetTotal() / count
+	var squares Decimal
+	for _, quote := range m.Quotes {
+		difference := quote.Quote.MinPrice - mean
+		squares += difference.Mul(difference)
+	}
+	return squares / count
+}
+
+// Rank sorts by total, max or variance, breaking ties on the total then the destination code.
+func (m MeetingPoints) Rank(rankBy string) {
+	score := (*MeetingPoint).GetTotal
+	switch rankBy {
+	case RankByMax:
+		score = (*MeetingPoint).GetMax
+	case RankByVariance:
+		score = (*MeetingPoint).GetVariance
+	}
+	sort.Sort(&meetingPointSorter{m, score})
+}
+
+func (m *meetingPointSorter) Len() int {
+	return len(m.points)
+}
+
+func (m *meetingPointSorter) Less(i, j int) bool {
+	left := m.points[i]
+	right := m.points[j]
+	if leftScore, rightScore := m.score(left), m.score(right); leftScore != rightScore {
+		return leftScore < rightScore
+	}
+	if leftTotal, rightTotal := left.GetTotal(), right.GetTotal(); leftTotal != rightTotal {
+		return leftTotal < rightTotal
+	}
+	return left.Destination.SkyscannerCode < right.Destination.SkyscannerCode
+}
+
+func (m *meetingPointSorter) Swap(i, j int) {
+	m.points[i], m.points[j] = m.points[j], m.points[i]
+}
@@ -0,0 +1,95 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newTestMeetingPoint(code string, prices ...string) *MeetingPoint {
+	point := &MeetingPoint{Destination: PlaceDto{SkyscannerCode: code}}
+	for _, price := range prices {
+		point.Quotes = append(point.Quotes, FullQuote{Quote: QuoteDto{MinPrice: ParseDecimalOP(price)}})
+	}
+	return point
+}
+
+func TestRankMeetingPoints(t *testing.T) {
+	points := MeetingPoints{
+		newTestMeetingPoint("BCN", "10", "100", "10"),
+		newTestMeetingPoint("MAD", "50", "50", "50"),
+		newTestMeetingPoint("VIE", "40", "60", "40")}
+	assert.Equal(t, NewDecimal(120), points[0].GetTotal())
+	assert.Equal(t, NewDecimal(100), points[0].GetMax())
+	assert.Equal(t, NewDecimal(1800), points[0].GetVariance())
+	assert.Equal(t, Decimal(0), points[1].GetVariance())
+
+	codes := func() []string {
+		results := make([]string, len(points))
+		for index, point := range points {
+			results[index] = point.Destination.SkyscannerCode
+		}
+		return results
+	}
+	points.Rank(RankByTotal)
+	assert.Equal(t, []string{"BCN", "VIE", "MAD"}, codes())
+	points.Rank(RankByMax)
+	assert.Equal(t, []string{"MAD", "VIE", "BCN"}, codes())
+	points.Rank(RankByVariance)
+	assert.Equal(t, []string{"MAD", "VIE", "BCN"}, codes())
+}
+
+const (
+	MeetingLGWLocation = TestDataBase + "meeting_lgw.json"
+	MeetingBERLocation = TestDataBase + "meeting_ber.json"
+)
+
+func TestFindMeetingPoints(t *testing.T) {
+	request := MeetingRequest{
+		Localisation:  Localisation{"GB", "GBP", "en-GB"},
+		Origins:       []string{"LGW", "BER"},
+		DepartureDate: "20160819",
+		ReturnDate:    "20160821"}
+	// Every browse of an origin, anywhere then by country then by town, gets the reply of that origin.
+	files := make(map[string]string)
+	for origin, fileName := range map[string]string{"LGW": MeetingLGWLocation, "BER": MeetingBERLocation} {
+		for _, destination := range []string{anywhere, "EU", "BCN", "MAD", "VIE", "FCO"} {
+			browse := BrowseRoutesRequest{request.Localisation, origin, destination, request.DepartureDate, request.ReturnDate}
+			files[browse.Url()] = fileName
+		}
+	}
+	engine := NewTestEngine(files)
+	codes := func(points MeetingPoints) []string {
+		results := make([]string, len(points))
+		for index, point := range points {
+			results[index] = point.Destination.SkyscannerCode
+		}
+		return results
+	}
+
+	results, err := FindMeetingPoints(engine, request)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"BCN", "VIE", "MAD", "FCO"}, codes(results))
+	assert.Equal(t, 2, len(results[0].Quotes))
+	assert.Equal(t, NewDecimal(10), results[0].Quotes[0].Quote.MinPrice)
+	assert.Equal(t, NewDecimal(100), results[0].Quotes[1].Quote.MinPrice)
+	assert.Equal(t, NewDecimal(110), results[0].GetTotal())
+
+	request.RankBy = RankByMax
+	results, err = FindMeetingPoints(engine, request)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"MAD", "VIE", "FCO", "BCN"}, codes(results))
+
+	request.RankBy = RankByVariance
+	results, err = FindMeetingPoints(engine, request)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"MAD", "FCO", "VIE", "BCN"}, codes(results))
+
+	request.Origins = []string{"LGW"}
+	_, err = FindMeetingPoints(engine, request)
+	assert.Equal(t, "Origins", err.(*ValidationError).Field)
+
+	request.Origins = []string{"LGW", "BER"}
+	request.RankBy = "median"
+	_, err = FindMeetingPoints(engine, request)
+	assert.Equal(t, "RankBy", err.(*ValidationError).Field)
+}
@@ -0,0 +1,84 @@
+package sklib
+
+import "fmt"
+
+// MockSearchAPI lets tests stub the SearchAPI calls they need,
+// the others fail with a "Not implemented" error.
+type MockSearchAPI struct {
+	BrowseFunc         func(request BrowseRoutesRequest) (FullQuotes, error)
+	SearchFunc         func(request SearchRequest, options SearchOptions) (Itineraries, error)
+	SearchProgressFunc func(request SearchRequest, options SearchOptions, progress LiveProgressCallback) (Itineraries, []SearchStatus, error)
+	ListLocalesFunc    func() ([]LocaleDto, error)
+	ListCurrenciesFunc func() ([]CurrencyDto, error)
+	ListCountriesFunc  func(locale string) ([]CountryDto, error)
+	AutosuggestFunc    func(localisation Localisation, query string) (PlaceSuggestions, error)
+	OverviewFunc       func(request OverviewRequest) (*OverviewReply, error)
+	DetailsFunc        func(request DetailsRequest) (*DetailsReply, error)
+}
+
+func notImplemented(name string) error {
+	return fmt.Errorf("Not implemented: %s", name)
+}
+
+func (m *MockSearchAPI) Browse(request BrowseRoutesRequest) (FullQuotes, error) {
+	if m.BrowseFunc == nil {
+		return nil, notImplemented("Browse")
+	}
+	return m.BrowseFunc(request)
+}
+
+func (m *MockSearchAPI) Search(request SearchRequest, options SearchOptions) (Itineraries, error) {
+	if m.SearchFunc == nil {
+		return nil, notImplemented("Search")
+	}
+	return m.SearchFunc(request, options)
+}
+
+func (m *MockSearchAPI) SearchProgress(request SearchRequest, options SearchOptions, progress LiveProgressCallback) (Itineraries, []SearchStatus, error) {
+	if m.SearchProgressFunc == nil {
+		return nil, nil, notImplemented("SearchProgress")
+	}
+	return m.SearchProgressFunc(request, options, progress)
+}
+
+func (m *MockSearchAPI) ListLocales() ([]LocaleDto, error) {
+	if m.ListLocalesFunc == nil {
+		return nil, notImplemented("ListLocales")
+	}
+	return m.ListLocalesFunc()
+}
+
+func (m *MockSearchAPI) ListCurrencies() ([]CurrencyDto, error) {
+	if m.ListCurrenciesFunc == nil {
+		return nil, notImplemented("ListCurrencies")
+	}
+	return m.ListCurrenciesFunc()
+}
+
+func (m *MockSearchAPI) ListCountries(locale string) ([]CountryDto, error) {
+	if m.ListCountriesFunc == nil {
+		return nil, notImplemented("ListCountries")
+	}
+	return m.ListCountriesFunc(locale)
+}
+
+func (m *MockSearchAPI) Autosuggest(localisation Localisation, query string) (PlaceSuggestions, error) {
+	if m.AutosuggestFunc == nil {
+		return nil, notImplemented("Autosuggest")
+	}
+	return m.AutosuggestFunc(localisation, query)
+}
+
+func (m *MockSearchAPI) Overview(request OverviewRequest) (*OverviewReply, error) {
+	if m.OverviewFunc == nil {
+		return nil, notImplemented("Overview")
+	}
+	return m.OverviewFunc(request)
+}
+
+func (m *MockSearchAPI) Details(request DetailsRequest) (*DetailsReply, error) {
+	if m.DetailsFunc == nil {
+		return nil, notImplemented("Details")
+	}
+	return m.DetailsFunc(request)
+}
@@ -1,18 +1,21 @@
 package sklib
 
 import (
+	"encoding/json"
 	"fmt"
-	"math"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type Place struct {
-	Name   string
-	Code   string
-	Type   string
-	Parent *Place
+	Name      string
+	Code      string
+	Type      string
+	Parent    *Place
+	Location  *time.Location `json:"-"`
+	Reference *ReferencePlace
 }
 
 type PlaceMap map[int]*Place
@@ -76,7 +79,7 @@ type AgentMap map[int]*Agent
 type PricingOption struct {
 	Agents      []*Agent
 	Age         time.Duration
-	Price       float64
+	Price       Decimal
 	DeeplinkUrl string
 }
 
@@ -86,6 +89,12 @@ type Itinerary struct {
 	OutboundLeg    *Leg
 	InboundLeg     *Leg
 	PricingOptions PricingOptions
+	Currency       *Currency
+	Conversion     *Conversion
+	// SearchOrigin and SearchDestination are the codes the live search ran with,
+	// which differ from the request's when nearby airports were searched.
+	SearchOrigin      string
+	SearchDestination string
 }
 
 type Itineraries []*Itinerary
@@ -138,12 +147,25 @@ func ReadLiveReply(input *LiveReply) (*FlightsData, error) {
 	if err != nil {
 		return nil, err
 	}
+	currency := findReplyCurrency(currencies, input.Query.Currency)
+	for _, itinerary := range itineraries {
+		itinerary.Currency = currency
+	}
 	return &FlightsData{
 			Currencies:  currencies,
 			Itineraries: itineraries},
 		nil
 }
 
+// findReplyCurrency prefers the currency sent with the reply to the embedded snapshot.
+func findReplyCurrency(currencies Currencies, code string) *Currency {
+	if currency, exists := currencies.Find(code); exists {
+		return currency
+	}
+	currency, _ := FindCurrency(code)
+	return currency
+}
+
 func getParentPlace(parentId string, mapping PlaceMap) *Place {
 	if len(parentId) == 0 {
 		return nil
@@ -154,7 +176,7 @@ func getParentPlace(parentId string, mapping PlaceMap) *Place {
 	}
 	parent, ok := mapping[parentIdInt]
 	if !ok {
-		fmt.Printf("%s %s %s\n", parentIdInt, parentId, len(mapping))
+		fmt.Fprintf(Progress, "%d %s %d\n", parentIdInt, parentId, len(mapping))
 		panic("Could not find parent " + parentId)
 	}
 	return parent
@@ -199,16 +221,31 @@ func (slice PlaceApiDtos) Swap(i, j int) {
 }
 
 func MapPlaces(inputNotSorted []PlaceApiDto) (PlaceMap, error) {
+	return MapPlacesWithReference(inputNotSorted, DefaultReference())
+}
+
+// MapPlacesWithReference enriches places found in the reference dataset with their time zone.
+func MapPlacesWithReference(inputNotSorted []PlaceApiDto, reference *ReferenceDB) (PlaceMap, error) {
 	input := PlaceApiDtos(inputNotSorted)
 	sort.Sort(input)
 
 	results := make(PlaceMap)
 	for _, placeDto := range input {
 		if _, exists := results[placeDto.Id]; exists {
-			return nil, fmt.Errorf("Duplicate place %s", placeDto)
+			return nil, fmt.Errorf("Duplicate place %v", placeDto)
 		}
 		parent := getParentPlace(string(placeDto.ParentId), results)
 		place := &Place{Code: placeDto.Code, Name: placeDto.Name, Parent: parent, Type: placeDto.Type}
+		if referencePlace, exists := reference.FindPlace(placeDto.Type, placeDto.Code); exists {
+			place.Reference = referencePlace
+			if len(referencePlace.TimeZone) != 0 {
+				location, err := LoadTimeZone(referencePlace.TimeZone)
+				if err != nil {
+					return nil, err
+				}
+				place.Location = location
+			}
+		}
 		results[placeDto.Id] = place
 	}
 	return results, nil
@@ -221,11 +258,11 @@ func ReadSegment(
 
 	origin := places[dto.OriginStation]
 	destination := places[dto.DestinationStation]
-	departure, err := ParseDateTime(dto.DepartureDateTime)
+	departure, err := ParseDateTimeIn(dto.DepartureDateTime, origin.GetLocation())
 	if err != nil {
 		return nil, err
 	}
-	arrival, err := ParseDateTime(dto.ArrivalDateTime)
+	arrival, err := ParseDateTimeIn(dto.ArrivalDateTime, destination.GetLocation())
 	if err != nil {
 		return nil, err
 	}
@@ -315,11 +352,11 @@ func ReadLeg(
 	if !exists {
 		return nil, fmt.Errorf("Missing destination %d", dto.DestinationStation)
 	}
-	departure, err := ParseDateTime(dto.Departure)
+	departure, err := ParseDateTimeIn(dto.Departure, origin.GetLocation())
 	if err != nil {
 		return nil, err
 	}
-	arrival, err := ParseDateTime(dto.Arrival)
+	arrival, err := ParseDateTimeIn(dto.Arrival, destination.GetLocation())
 	if err != nil {
 		return nil, err
 	}
@@ -382,7 +419,7 @@ func ReadAgent(dto AgentApiDto) (*Agent, error) {
 /*
 	Agents      []*Agent
 	Age         time.Duration
-	Price       float64
+	Price       Decimal
 	DeeplinkUrl string
 */
 func FindCarriers(ids []int, carriers CarrierMap) (Carriers, error) {
@@ -500,9 +537,12 @@ func ReadItinerary(dto ItineraryApiDto, legs LegMap, agentMap AgentMap) (*Itiner
 	if !exists {
 		return nil, fmt.Errorf("Missing leg %s", dto.OutboundLegId)
 	}
-	inbound, exists := legs[dto.InboundLegId]
-	if !exists {
-		return nil, fmt.Errorf("Missing leg %s", dto.InboundLegId)
+	var inbound *Leg
+	if len(dto.InboundLegId) != 0 {
+		inbound, exists = legs[dto.InboundLegId]
+		if !exists {
+			return nil, fmt.Errorf("Missing leg %s", dto.InboundLegId)
+		}
 	}
 	pos, err := ReadPricingOptions(dto.PricingOptions, agentMap)
 	if err != nil {
@@ -529,16 +569,22 @@ type DirectFilter struct {
 }
 
 func (m *DirectFilter) Filter(itinerary *Itinerary) bool {
-	direct := itinerary.OutboundLeg.IsDirect() && itinerary.InboundLeg.IsDirect()
+	direct := itinerary.OutboundLeg.IsDirect()
+	if itinerary.IsReturn() {
+		direct = direct && itinerary.InboundLeg.IsDirect()
+	}
 	return direct == m.Direct
 }
 
+// Filter lets one-way itineraries through filters on the return leg.
 func (m *DepartureAfterFilter) Filter(itinerary *Itinerary) bool {
 	var departureAt time.Time
 	if m.Departure {
-		departureAt = itinerary.OutboundLeg.Departure
+		departureAt = itinerary.OutboundLeg.GetLocalDeparture()
+	} else if itinerary.IsReturn() {
+		departureAt = itinerary.InboundLeg.GetLocalDeparture()
 	} else {
-		departureAt = itinerary.InboundLeg.Departure
+		return true
 	}
 	departureDuration := time.Minute*time.Duration(departureAt.Minute()) +
 		time.Hour*time.Duration(departureAt.Hour())
@@ -564,6 +610,42 @@ func (m *Leg) IsDirect() bool {
 	return len(m.Stops) == 0
 }
 
+// GetLocalDeparture is the departure time on the wall clock of the origin airport.
+func (m *Leg) GetLocalDeparture() time.Time {
+	return m.Departure.In(m.Origin.GetLocation())
+}
+
+// GetLocalArrival is the arrival time on the wall clock of the destination airport.
+func (m *Leg) GetLocalArrival() time.Time {
+	return m.Arrival.In(m.Destination.GetLocation())
+}
+
+// UnmarshalJSON restores the Location, which is not serialised, from the reference place,
+// or else from the default reference dataset, so decoded itineraries filter on the same local times.
+func (m *Place) UnmarshalJSON(data []byte) error {
+	type jsonPlace Place
+	if err := json.Unmarshal(data, (*jsonPlace)(m)); err != nil {
+		return err
+	}
+	var err error
+	if m.Reference != nil && len(m.Reference.TimeZone) != 0 {
+		m.Location, err = LoadTimeZone(m.Reference.TimeZone)
+	} else {
+		m.Location, err = FindLocation(m.Code)
+	}
+	return err
+}
+
+// GetLocation falls back on the parent city, then on UTC for unknown places.
+func (m *Place) GetLocation() *time.Location {
+	for place := m; place != nil; place = place.Parent {
+		if place.Location != nil {
+			return place.Location
+		}
+	}
+	return time.UTC
+}
+
 func ApplyFilter(input Itineraries, filter ItineraryFilter) Itineraries {
 	results := make(Itineraries, 0)
 	for _, itinerary := range input {
@@ -584,14 +666,55 @@ func (m *Leg) Display() string {
 		m.Arrival)
 }
 
-func (m *Itinerary) GetPrice() float64 {
-	price := math.MaxFloat64
-	for _, po := range m.PricingOptions {
-		price = math.Min(price, po.Price)
+func (m *Itinerary) IsReturn() bool {
+	return m.InboundLeg != nil
+}
+
+// GetPrice is the cheapest pricing option, whether the itinerary is one-way or return.
+func (m *Itinerary) GetPrice() Decimal {
+	return m.PricingOptions.GetPrice()
+}
+
+func (m *Itinerary) GetMoney() Money {
+	return Money{m.GetPrice(), m.Currency}
+}
+
+func (m *Itinerary) Display() string {
+	legs := make([]string, 0, 2)
+	for _, leg := range m.GetLegs() {
+		legs = append(legs, leg.Display())
+	}
+	return fmt.Sprintf("%s %s", strings.Join(legs, " | "), m.GetMoney())
+}
+
+// GetLegs returns the outbound leg followed by the inbound leg, if any.
+func (m *Itinerary) GetLegs() []*Leg {
+	if m.IsReturn() {
+		return []*Leg{m.OutboundLeg, m.InboundLeg}
+	}
+	return []*Leg{m.OutboundLeg}
+}
+
+func (m PricingOptions) GetPrice() Decimal {
+	price := MaxDecimal
+	for _, po := range m {
+		if po.Price < price {
+			price = po.Price
+		}
 	}
 	return price
 }
 
+func (m PricingOptions) GetCheapest() *PricingOption {
+	var cheapest *PricingOption
+	for _, po := range m {
+		if cheapest == nil || po.Price < cheapest.Price {
+			cheapest = po
+		}
+	}
+	return cheapest
+}
+
 func (a Itineraries) Len() int           { return len(a) }
 func (a Itineraries) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a Itineraries) Less(i, j int) bool { return a[i].GetPrice() < a[j].GetPrice() }
@@ -599,7 +722,9 @@ func (a Itineraries) Less(i, j int) bool { return a[i].GetPrice() < a[j].GetPric
 func (m *Itinerary) Carriers() Carriers {
 	carriers := make(Carriers, 0)
 	carriers = append(carriers, m.OutboundLeg.Carriers...)
-	carriers = append(carriers, m.InboundLeg.Carriers...)
+	if m.IsReturn() {
+		carriers = append(carriers, m.InboundLeg.Carriers...)
+	}
 	return carriers
 }
 
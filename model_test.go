@@ -3,6 +3,7 @@ package sklib
 import (
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestMapCarriers(t *testing.T) {
@@ -67,3 +68,57 @@ func TestReadSegments(t *testing.T) {
 	}
 	assert.Equal(t, len(reply.Segments), len(segments))
 }
+
+func TestReadOneWayLiveReply(t *testing.T) {
+	var reply LiveReply
+	ParseFromJsonFile(LiveOneWayJsonLocation, &reply)
+	data, err := ReadLiveReply(&reply)
+	if err != nil {
+		panic(err)
+	}
+	assert.Equal(t, 3, len(data.Itineraries))
+	for _, itinerary := range data.Itineraries {
+		assert.False(t, itinerary.IsReturn())
+		assert.Nil(t, itinerary.InboundLeg)
+		assert.Equal(t, len(itinerary.OutboundLeg.Carriers), len(itinerary.Carriers()))
+	}
+	assert.Equal(t, ParseDecimalOP("39.82"), data.Itineraries[0].GetPrice())
+
+	directs := ApplyFilter(data.Itineraries, CompositeFilter{}.AppendDirectOnly())
+	assert.Equal(t, 2, len(directs))
+
+	limit := 12 * time.Hour
+	inbound := CompositeFilter{}.AppendTimeFilter(&limit, false, false)
+	assert.Equal(t, 3, len(ApplyFilter(data.Itineraries, inbound)))
+	outbound := CompositeFilter{}.AppendTimeFilter(&limit, false, true)
+	assert.Equal(t, 1, len(ApplyFilter(data.Itineraries, outbound)))
+}
+
+func TestLegTimeZones(t *testing.T) {
+	itineraries := GetTestOneWayItineraries()
+	leg := itineraries[0].OutboundLeg
+	assert.Equal(t, "LGW", leg.Origin.Code)
+	assert.Equal(t, "Europe/London", leg.Departure.Location().String())
+	assert.Equal(t, "Europe/Vienna", leg.Arrival.Location().String())
+	// 08:20 in London to 11:35 in Vienna is 2h15.
+	assert.Equal(t, leg.Duration, leg.Arrival.Sub(leg.Departure))
+	assert.Equal(t, 8, leg.GetLocalDeparture().Hour())
+	assert.Equal(t, 11, leg.GetLocalArrival().Hour())
+
+	segment := leg.Segments[0]
+	assert.Equal(t, segment.Duration, segment.Arrival.Sub(segment.Departure))
+}
+
+func TestPlaceGetLocation(t *testing.T) {
+	reply := GetTestLiveReply()
+	places, err := MapPlaces(reply.Places)
+	if err != nil {
+		panic(err)
+	}
+	assert.Equal(t, "Europe/London", places[13542].GetLocation().String())
+	assert.Equal(t, "Europe/London", places[4698].GetLocation().String())
+	assert.Nil(t, places[247].Location)
+	assert.Equal(t, time.UTC, places[247].GetLocation())
+	var unknown *Place
+	assert.Equal(t, time.UTC, unknown.GetLocation())
+}
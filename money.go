@@ -0,0 +1,83 @@
+package sklib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Money is an amount in a currency, formatted with the currency's display rules.
+// A nil Currency formats as a bare amount with 2 decimals.
+type Money struct {
+	Amount   Decimal
+	Currency *Currency
+}
+
+// FindCurrency looks a currency code up in the embedded localisation snapshot, ignoring case.
+func FindCurrency(code string) (*Currency, bool) {
+	for _, dto := range localisationSnapshot.Currencies {
+		if strings.EqualFold(dto.Code, code) {
+			currency := Currency(dto)
+			return &currency, true
+		}
+	}
+	return nil, false
+}
+
+// Find looks for a currency by code, ignoring case.
+func (m Currencies) Find(code string) (*Currency, bool) {
+	for _, currency := range m {
+		if strings.EqualFold(currency.Code, code) {
+			return currency, true
+		}
+	}
+	return nil, false
+}
+
+func (m Money) String() string {
+	return m.Currency.Format(m.Amount)
+}
+
+// Format rounds the amount to the RoundingCoefficient and DecimalDigits,
+// then places the symbol and separators as the currency says.
+func (m *Currency) Format(amount Decimal) string {
+	if m == nil {
+		return amount.StringFixed(2)
+	}
+	digits := amount.Round(m.RoundingCoefficient).StringFixed(m.DecimalDigits)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign = "-"
+		digits = digits[1:]
+	}
+	integer, fraction := digits, ""
+	if index := strings.IndexByte(digits, '.'); index >= 0 {
+		integer, fraction = digits[:index], digits[index+1:]
+	}
+	number := groupThousands(integer, m.ThousandsSeparator)
+	if len(fraction) != 0 {
+		number += m.DecimalSeparator + fraction
+	}
+	space := ""
+	if m.SpaceBetweenAmountAndSymbol {
+		space = " "
+	}
+	if m.SymbolOnLeft {
+		return fmt.Sprintf("%s%s%s%s", sign, m.Symbol, space, number)
+	}
+	return fmt.Sprintf("%s%s%s%s", sign, number, space, m.Symbol)
+}
+
+func groupThousands(integer string, separator string) string {
+	if len(integer) <= 3 {
+		return integer
+	}
+	head := len(integer) % 3
+	if head == 0 {
+		head = 3
+	}
+	groups := []string{integer[:head]}
+	for index := head; index < len(integer); index += 3 {
+		groups = append(groups, integer[index:index+3])
+	}
+	return strings.Join(groups, separator)
+}
@@ -0,0 +1,44 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCurrencyFormat(t *testing.T) {
+	gbp, _ := FindCurrency("GBP")
+	assert.Equal(t, "£1,234.50", gbp.Format(ParseDecimalOP("1234.5")))
+	assert.Equal(t, "£12.35", gbp.Format(ParseDecimalOP("12.345")))
+	assert.Equal(t, "-£3.00", gbp.Format(NewDecimal(-3)))
+
+	eur, _ := FindCurrency("eur")
+	assert.Equal(t, "1\u00a0234\u00a0567,89 €", eur.Format(ParseDecimalOP("1234567.891")))
+
+	jpy, _ := FindCurrency("JPY")
+	assert.Equal(t, "¥12,346", jpy.Format(ParseDecimalOP("12345.6")))
+
+	rounded := Currency{Code: "XXX", Symbol: "X", ThousandsSeparator: ",", DecimalSeparator: ".", RoundingCoefficient: 5}
+	assert.Equal(t, "1,235X", rounded.Format(NewDecimal(1233)))
+
+	_, exists := FindCurrency("ZZZ")
+	assert.False(t, exists)
+	assert.Equal(t, "42.10", Money{ParseDecimalOP("42.1"), nil}.String())
+}
+
+func TestItineraryDisplay(t *testing.T) {
+	data, err := ReadLiveReply(GetTestLiveReply())
+	if err != nil {
+		panic(err)
+	}
+	itinerary := data.Itineraries[0]
+	assert.Equal(t, "GBP", itinerary.Currency.Code)
+	assert.Contains(t, itinerary.Display(), itinerary.GetMoney().String())
+	assert.Contains(t, itinerary.Display(), "£")
+}
+
+func TestFullQuoteDisplay(t *testing.T) {
+	reply := ParseBrowseRoutesReplyJson(ReadOrPanic(AnywhereLocationJson))
+	quote := reply.GetBestQuotes()[0]
+	assert.Equal(t, "GBP", quote.Currency.Code)
+	assert.Equal(t, quote.Destination.Name+" "+quote.Currency.Format(quote.Quote.MinPrice), quote.Display())
+}
@@ -0,0 +1,167 @@
+package sklib
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const defaultMaxOptionsPerLeg = 5
+
+type LegRequest struct {
+	Origin      string
+	Destination string
+	Date        string
+}
+
+// MultiCityRequest is an ordered list of one-way legs, for open-jaw and multi-city trips.
+// MaxOptionsPerLeg caps how many of the cheapest itineraries of each leg are combined.
+type MultiCityRequest struct {
+	Localisation     Localisation
+	Legs             []LegRequest
+	MaxOptionsPerLeg int
+	LiveOptions
+}
+
+// MultiCityItinerary generalises Itinerary to N legs.
+// Itineraries holds the one-way itinerary booked for each leg,
+// PricingOptions the combined price of the cheapest option of every leg.
+type MultiCityItinerary struct {
+	Legs           []*Leg
+	Itineraries    Itineraries
+	PricingOptions PricingOptions
+}
+
+type MultiCityItineraries []*MultiCityItinerary
+
+func (m *MultiCityRequest) Validate() error {
+	if len(m.Legs) < 2 {
+		return &ValidationError{"Legs", fmt.Sprintf("expected at least 2 legs, got %d", len(m.Legs))}
+	}
+	var previous time.Time
+	for index, leg := range m.Legs {
+		if len(leg.Origin) == 0 || len(leg.Destination) == 0 {
+			return &ValidationError{"Legs", fmt.Sprintf("leg %d has no origin or destination", index)}
+		}
+		date, err := ParseUrlDate(leg.Date)
+		if err != nil {
+			return &ValidationError{"Legs", fmt.Sprintf("leg %d: %s", index, err)}
+		}
+		if date.Before(previous) {
+			return &ValidationError{"Legs", fmt.Sprintf("leg %d departs before leg %d", index, index-1)}
+		}
+		previous = date
+	}
+	return m.LiveOptions.Validate()
+}
+
+func (m *MultiCityRequest) LiveRequests() []LiveRequest {
+	results := make([]LiveRequest, len(m.Legs))
+	for index, leg := range m.Legs {
+		results[index] = NewLiveRequest(
+			m.Localisation,
+			leg.Origin,
+			leg.Destination,
+			leg.Date,
+			"",
+			m.LiveOptions)
+	}
+	return results
+}
+
+func (m *MultiCityRequest) GetMaxOptionsPerLeg() int {
+	if m.MaxOptionsPerLeg <= 0 {
+		return defaultMaxOptionsPerLeg
+	}
+	return m.MaxOptionsPerLeg
+}
+
+// SearchMultiCity runs a one-way live search per leg and prices the combinations.
+func SearchMultiCity(engine RequestEngine, request MultiCityRequest) (MultiCityItineraries, error) {
+	if err := ValidateLocalisation(request.Localisation); err != nil {
+		return nil, err
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	legs := make([]Itineraries, len(request.Legs))
+	for index, liveRequest := range request.LiveRequests() {
+		fmt.Fprintln(Progress, "Searching leg", index, liveRequest.Origin, liveRequest.Destination)
+		flightsData, err := RunLiveRequest(engine, liveRequest)
+		if err != nil {
+			return nil, err
+		}
+		legs[index] = GetCheapestItineraries(flightsData.Itineraries, request.GetMaxOptionsPerLeg())
+	}
+	results := CombineItineraries(legs)
+	sort.Sort(results)
+	return results, nil
+}
+
+func GetCheapestItineraries(input Itineraries, count int) Itineraries {
+	results := make(Itineraries, 0, len(input))
+	for _, itinerary := range input {
+		if len(itinerary.PricingOptions) != 0 {
+			results = append(results, itinerary)
+		}
+	}
+	sort.Sort(results)
+	if len(results) > count {
+		results = results[:count]
+	}
+	return results
+}
+
+// CombineItineraries builds every combination of one itinerary per leg,
+// skipping those where a leg departs before the previous one has landed.
+func CombineItineraries(legs []Itineraries) MultiCityItineraries {
+	results := make(MultiCityItineraries, 0)
+	if len(legs) == 0 {
+		return results
+	}
+	var combine func(index int, chosen Itineraries)
+	combine = func(index int, chosen Itineraries) {
+		if index == len(legs) {
+			results = append(results, NewMultiCityItinerary(chosen))
+			return
+		}
+		for _, itinerary := range legs[index] {
+			if index > 0 && !connects(chosen[index-1], itinerary) {
+				continue
+			}
+			combine(index+1, append(chosen[:index], itinerary))
+		}
+	}
+	combine(0, make(Itineraries, 0, len(legs)))
+	return results
+}
+
+func connects(previous *Itinerary, next *Itinerary) bool {
+	return next.OutboundLeg.Departure.After(previous.OutboundLeg.Arrival)
+}
+
+func NewMultiCityItinerary(itineraries Itineraries) *MultiCityItinerary {
+	legs := make([]*Leg, 0, len(itineraries))
+	combined := &PricingOption{Agents: make([]*Agent, 0, len(itineraries))}
+	for _, itinerary := range itineraries {
+		legs = append(legs, itinerary.GetLegs()...)
+		cheapest := itinerary.PricingOptions.GetCheapest()
+		combined.Agents = append(combined.Agents, cheapest.Agents...)
+		combined.Price += cheapest.Price
+		if cheapest.Age > combined.Age {
+			combined.Age = cheapest.Age
+		}
+	}
+	return &MultiCityItinerary{
+		Legs:           legs,
+		Itineraries:    append(Itineraries(nil), itineraries...),
+		PricingOptions: PricingOptions{combined}}
+}
+
+func (m *MultiCityItinerary) GetPrice() Decimal {
+	return m.PricingOptions.GetPrice()
+}
+
+func (a MultiCityItineraries) Len() int           { return len(a) }
+func (a MultiCityItineraries) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a MultiCityItineraries) Less(i, j int) bool { return a[i].GetPrice() < a[j].GetPrice() }
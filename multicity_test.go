@@ -0,0 +1,60 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func GetTestOneWayItineraries() Itineraries {
+	var reply LiveReply
+	ParseFromJsonFile(LiveOneWayJsonLocation, &reply)
+	data, err := ReadLiveReply(&reply)
+	if err != nil {
+		panic(err)
+	}
+	return data.Itineraries
+}
+
+func TestMultiCityRequestValidate(t *testing.T) {
+	request := MultiCityRequest{
+		Localisation: Localisation{"GB", "GBP", "en-GB"},
+		Legs: []LegRequest{
+			{"LON", "VIE", "20161101"},
+			{"BUD", "LON", "20161105"}}}
+	assert.Nil(t, request.Validate())
+
+	liveRequests := request.LiveRequests()
+	assert.Equal(t, 2, len(liveRequests))
+	assert.Equal(t, "BUD", liveRequests[1].Origin)
+	assert.False(t, liveRequests[1].IsReturn())
+
+	request.Legs[1].Date = "20161031"
+	assert.NotNil(t, request.Validate())
+	request.Legs = request.Legs[:1]
+	assert.NotNil(t, request.Validate())
+}
+
+func TestCombineItineraries(t *testing.T) {
+	itineraries := GetTestOneWayItineraries()
+	// 08:20-11:35 then 17:00-20:10 connects, the reverse does not.
+	morning, evening := itineraries[0], itineraries[1]
+	combinations := CombineItineraries([]Itineraries{
+		{morning, evening},
+		{morning, evening}})
+
+	assert.Equal(t, 1, len(combinations))
+	combination := combinations[0]
+	assert.Equal(t, 2, len(combination.Legs))
+	assert.Equal(t, morning.OutboundLeg, combination.Legs[0])
+	assert.Equal(t, evening.OutboundLeg, combination.Legs[1])
+	assert.Equal(t, ParseDecimalOP("94.16"), combination.GetPrice())
+	assert.Equal(t, 2, len(combination.PricingOptions[0].Agents))
+}
+
+func TestGetCheapestItineraries(t *testing.T) {
+	itineraries := GetTestOneWayItineraries()
+	cheapest := GetCheapestItineraries(itineraries, 2)
+	assert.Equal(t, 2, len(cheapest))
+	assert.Equal(t, ParseDecimalOP("39.82"), cheapest[0].GetPrice())
+	assert.Equal(t, ParseDecimalOP("54.34"), cheapest[1].GetPrice())
+}
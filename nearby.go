@@ -0,0 +1,110 @@
+package sklib
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	NearbyCity      = "city"
+	NearbyRadius    = "radius"
+	earthRadiusKm   = 6371.0
+	maxNearbyRadius = 500.0
+)
+
+// NearbyOptions expands a place to the airports of its city, or to the airports within RadiusKm.
+// The zero value searches the place as given.
+type NearbyOptions struct {
+	Mode     string
+	RadiusKm float64
+}
+
+func (m *NearbyOptions) Validate(field string) error {
+	switch m.Mode {
+	case "", NearbyCity:
+		return nil
+	case NearbyRadius:
+		if m.RadiusKm <= 0 || m.RadiusKm > maxNearbyRadius {
+			return &ValidationError{field, fmt.Sprintf("radius must be between 0 and %.0fkm", maxNearbyRadius)}
+		}
+		return nil
+	default:
+		return &ValidationError{field, fmt.Sprintf("unknown mode %s", m.Mode)}
+	}
+}
+
+// Distance is the great-circle distance in kilometres, using the haversine formula.
+func Distance(from *ReferencePlace, to *ReferencePlace) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	latitudeDelta := toRadians(to.Latitude - from.Latitude)
+	longitudeDelta := toRadians(to.Longitude - from.Longitude)
+	a := math.Pow(math.Sin(latitudeDelta/2), 2) +
+		math.Cos(toRadians(from.Latitude))*math.Cos(toRadians(to.Latitude))*math.Pow(math.Sin(longitudeDelta/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// GetNearbyAirports lists the airports within radiusKm of a place, closest first.
+func (m *ReferenceDB) GetNearbyAirports(place *ReferencePlace, radiusKm float64) ReferencePlaces {
+	results := make(ReferencePlaces, 0)
+	distances := make(map[string]float64)
+	for _, airport := range m.airports {
+		distance := Distance(place, airport)
+		if distance <= radiusKm {
+			results = append(results, airport)
+			distances[airport.Code] = distance
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		left := distances[results[i].Code]
+		right := distances[results[j].Code]
+		if left == right {
+			return results[i].Code < results[j].Code
+		}
+		return left < right
+	})
+	return results
+}
+
+// ExpandPlace lists the codes to search for a place, the place itself when it is unknown
+// or has no airport to expand to.
+func (m *ReferenceDB) ExpandPlace(code string, options NearbyOptions) []string {
+	place, exists := m.Find(code)
+	if !exists {
+		return []string{code}
+	}
+	var airports ReferencePlaces
+	switch options.Mode {
+	case NearbyCity:
+		city := place.Code
+		if place.Type == AirportValue && len(place.Parent) != 0 {
+			city = place.Parent
+		}
+		airports = m.GetAirports(city)
+	case NearbyRadius:
+		airports = m.GetNearbyAirports(place, options.RadiusKm)
+	}
+	if len(airports) == 0 {
+		return []string{code}
+	}
+	results := make([]string, len(airports))
+	for index, airport := range airports {
+		results[index] = airport.Code
+	}
+	return results
+}
+
+// ExpandDestinations applies the destination options to every destination, without duplicates.
+func (m *ReferenceDB) ExpandDestinations(destinations []string, options NearbyOptions) []string {
+	seen := make(map[string]bool)
+	results := make([]string, 0, len(destinations))
+	for _, destination := range destinations {
+		for _, code := range m.ExpandPlace(destination, options) {
+			if !seen[code] {
+				seen[code] = true
+				results = append(results, code)
+			}
+		}
+	}
+	return results
+}
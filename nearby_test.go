@@ -0,0 +1,60 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	reference := DefaultReference()
+	lhr, _ := reference.FindAirport("LHR")
+	vie, _ := reference.FindAirport("VIE")
+	assert.InDelta(t, 1274, Distance(lhr, vie), 5)
+	assert.Equal(t, 0.0, Distance(lhr, lhr))
+}
+
+func TestExpandPlace(t *testing.T) {
+	reference := DefaultReference()
+	london := []string{"LCY", "LGW", "LHR", "LTN", "SEN", "STN"}
+	assert.Equal(t, london, reference.ExpandPlace("LON", NearbyOptions{Mode: NearbyCity}))
+	assert.Equal(t, london, reference.ExpandPlace("LGW", NearbyOptions{Mode: NearbyCity}))
+	assert.Equal(t, []string{"LGW"}, reference.ExpandPlace("LGW", NearbyOptions{}))
+	assert.Equal(t, []string{"XXX"}, reference.ExpandPlace("XXX", NearbyOptions{Mode: NearbyCity}))
+
+	nearby := reference.ExpandPlace("LGW", NearbyOptions{Mode: NearbyRadius, RadiusKm: 45})
+	assert.Equal(t, "LGW", nearby[0])
+	assert.Contains(t, nearby, "LHR")
+	assert.NotContains(t, nearby, "STN")
+
+	assert.Equal(t, []string{"VIE", "LCY", "LGW", "LHR", "LTN", "SEN", "STN"},
+		reference.ExpandDestinations([]string{"VIE", "LON", "LHR"}, NearbyOptions{Mode: NearbyCity}))
+
+	options := NearbyOptions{Mode: NearbyRadius}
+	assert.NotNil(t, options.Validate("OriginNearby"))
+	options = NearbyOptions{Mode: "country"}
+	assert.NotNil(t, options.Validate("OriginNearby"))
+}
+
+func TestSearchNearbyOrigins(t *testing.T) {
+	files := make(map[string]string)
+	for _, origin := range []string{"LCY", "LGW", "LHR", "LTN", "SEN", "STN"} {
+		request := NewLiveRequest(Localisation{"GB", "GBP", "en-GB"}, origin, "VIE", "20161101", "", LiveOptions{})
+		files[liveURL+"?"+request.Values().Encode()] = LiveOneWayJsonLocation
+	}
+	engine := NewTestEngine(files)
+	results, err := Search(engine, SearchRequest{
+		Localisation:  Localisation{"GB", "GBP", "en-GB"},
+		Origin:        "LHR",
+		Destinations:  []string{"VIE"},
+		DepartureDate: "20161101",
+		OriginNearby:  NearbyOptions{Mode: NearbyCity}})
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(engine.Calls))
+	assert.Equal(t, 18, len(results))
+	origins := make(map[string]int)
+	for _, itinerary := range results {
+		origins[itinerary.SearchOrigin]++
+		assert.Equal(t, "VIE", itinerary.SearchDestination)
+	}
+	assert.Equal(t, 3, origins["SEN"])
+}
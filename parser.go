@@ -17,12 +17,21 @@ const (
 	DateFormatUrl         = "20060102"
 	DateFormatForm        = "2006-01-02"
 	DateTimeFormat        = "2006-01-02T15:04:05"
+	EconomyCabin          = "Economy"
+	PremiumEconomyCabin   = "PremiumEconomy"
+	BusinessCabin         = "Business"
+	FirstCabin            = "First"
+	maxPassengers         = 8
 )
 
 func ParseDateTime(dateTime string) (time.Time, error) {
 	return time.Parse(DateTimeFormat, dateTime)
 }
 
+func ParseDateTimeIn(dateTime string, location *time.Location) (time.Time, error) {
+	return time.ParseInLocation(DateTimeFormat, dateTime, location)
+}
+
 func ParseBrowseRoutesReplyJson(data []byte) *BrowseRoutesReply {
 	anywhere := &BrowseRoutesReply{}
 	err := ParseJson(data, anywhere)
@@ -40,6 +49,13 @@ func FormatDateToForm(input string) string {
 	return date.Format(DateFormatForm)
 }
 
+func formatOptionalDateToForm(input string) string {
+	if len(input) == 0 {
+		return ""
+	}
+	return FormatDateToForm(input)
+}
+
 func ParseUrlDate(input string) (time.Time, error) {
 	return time.Parse(DateFormatUrl, input)
 }
@@ -53,6 +69,15 @@ func ParseUrlDateOP(input string) time.Time {
 
 }
 
+// ParseReply decodes either an XML or a JSON reply, depending on its first character.
+func ParseReply(data []byte, output interface{}) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) != 0 && trimmed[0] == '<' {
+		return ParseXml(trimmed, output)
+	}
+	return ParseJson(trimmed, output)
+}
+
 func ParseXml(data []byte, output interface{}) error {
 	decoder := xml.NewDecoder(bytes.NewReader(data))
 	return decoder.Decode(output)
@@ -18,7 +18,9 @@ const (
 	AnywhereLocationJson     = AnywhereLocationBase + ".json"
 	LivePendingLocation      = TestDataBase + "live_pending.xml"
 	LiveCompleteLocation     = TestDataBase + "live_complete.xml"
+	LivePendingJsonLocation  = TestDataBase + "live_pending.json"
 	LiveCompleteJsonLocation = TestDataBase + "live_complete.json"
+	LiveOneWayJsonLocation   = TestDataBase + "live_oneway.json"
 	CurrenciesLocation       = TestDataBase + "currencies.xml"
 	LocalesLocation          = TestDataBase + "locales.xml"
 	CountriesLocation        = TestDataBase + "countries.en-GB.xml"
@@ -450,7 +452,7 @@ func TestParsePricingOptionApi(t *testing.T) {
 	assert.Equal(t, 1, len(e.Agents))
 	assert.Equal(t, 2363321, e.Agents[0])
 	assert.Equal(t, 17, e.QuoteAgeInMinutes)
-	assert.Equal(t, 67.06, e.Price)
+	assert.Equal(t, ParseDecimalOP("67.06"), e.Price)
 	assert.Equal(t, 809, len(e.DeeplinkUrl))
 }
 
@@ -543,7 +545,7 @@ func TestParseQuoteDto(t *testing.T) {
 	}
 
 	assert.Equal(t, 1, e.QuoteId)
-	assert.Equal(t, 326.0, e.MinPrice)
+	assert.Equal(t, NewDecimal(326), e.MinPrice)
 	assert.Equal(t, false, e.Direct)
 	assert.Equal(t, 65698, e.OutboundLeg.OriginId)
 	assert.Equal(t, 65698, e.InboundLeg.DestinationId)
@@ -623,7 +625,7 @@ func TestAnywhere(t *testing.T) {
 }
 
 func OpenOrPanic(fileName string) *os.File {
-	file, err := os.Open(AnywhereLocationJson)
+	file, err := os.Open(fileName)
 	if err != nil {
 		panic(err)
 	}
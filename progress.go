@@ -0,0 +1,93 @@
+package sklib
+
+import (
+	"fmt"
+	"sync"
+)
+
+type AgentStatus struct {
+	Name   string
+	Status string
+}
+
+// LiveProgress is a poll round of a live session: the session status,
+// UpdatesPending or UpdatesComplete, the status of each agent,
+// and the itineraries not seen in the earlier rounds.
+type LiveProgress struct {
+	Origin      string
+	Destination string
+	Status      string
+	Agents      []AgentStatus
+	Itineraries Itineraries
+}
+
+// LiveProgressCallback is called from the goroutines running the live sessions,
+// so concurrently when a search runs several of them.
+type LiveProgressCallback func(progress *LiveProgress)
+
+// progressTracker turns poll rounds into LiveProgress, remembering the itineraries already reported.
+type progressTracker struct {
+	origin      string
+	destination string
+	seen        map[string]bool
+	callback    LiveProgressCallback
+	lock        sync.Mutex
+}
+
+func newProgressTracker(origin string, destination string, callback LiveProgressCallback) *progressTracker {
+	return &progressTracker{
+		origin:      origin,
+		destination: destination,
+		seen:        make(map[string]bool),
+		callback:    callback}
+}
+
+// round skips the payloads it cannot read, the session will fail on the final one if it is broken.
+func (m *progressTracker) round(payload []byte) {
+	var reply LiveReply
+	if err := ParseJson(payload, &reply); err != nil {
+		fmt.Fprintln(Progress, "Skipping poll round:", err)
+		return
+	}
+	data, err := ReadLiveReply(&reply)
+	if err != nil {
+		fmt.Fprintln(Progress, "Skipping poll round:", err)
+		return
+	}
+	progress := &LiveProgress{
+		Origin:      m.origin,
+		Destination: m.destination,
+		Status:      reply.Status,
+		Agents:      make([]AgentStatus, len(reply.Agents)),
+		Itineraries: make(Itineraries, 0)}
+	for index, agent := range reply.Agents {
+		progress.Agents[index] = AgentStatus{agent.Name, agent.Status}
+	}
+	m.lock.Lock()
+	// ReadLiveReply keeps the order of the itinerary DTOs, which hold the leg ids.
+	for index, dto := range reply.Itineraries {
+		key := dto.OutboundLegId + "|" + dto.InboundLegId
+		if m.seen[key] {
+			continue
+		}
+		m.seen[key] = true
+		itinerary := data.Itineraries[index]
+		itinerary.SearchOrigin = m.origin
+		itinerary.SearchDestination = m.destination
+		progress.Itineraries = append(progress.Itineraries, itinerary)
+	}
+	m.lock.Unlock()
+	m.callback(progress)
+}
+
+// RunLiveRequestProgress reports every poll round of the session to progress,
+// engines that are not ProgressEngines only report the final round.
+func RunLiveRequestProgress(engine RequestEngine, request LiveRequest, progress LiveProgressCallback) (*FlightsData, error) {
+	if progress == nil {
+		return RunLiveRequest(engine, request)
+	}
+	tracker := newProgressTracker(request.Origin, request.Destination, progress)
+	return runLiveRequest(request, func() ([]byte, error) {
+		return PostAndPollProgress(engine, liveURL, request.Values(), tracker.round)
+	})
+}
@@ -0,0 +1,86 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+)
+
+// RoundsEngine replays poll rounds from testdata, the last one being the session result.
+type RoundsEngine struct {
+	Rounds []string
+}
+
+func (m *RoundsEngine) Get(url string) ([]byte, error) {
+	return ReadOrPanic(m.Rounds[len(m.Rounds)-1]), nil
+}
+
+func (m *RoundsEngine) PostAndPoll(url string, form url.Values) ([]byte, error) {
+	return m.PostAndPollProgress(url, form, func(payload []byte) {})
+}
+
+func (m *RoundsEngine) PostAndPollProgress(url string, form url.Values, progress PollCallback) ([]byte, error) {
+	var payload []byte
+	for _, fileName := range m.Rounds {
+		payload = ReadOrPanic(fileName)
+		progress(payload)
+	}
+	return payload, nil
+}
+
+func TestRunLiveRequestProgress(t *testing.T) {
+	engine := &RoundsEngine{[]string{LivePendingJsonLocation, LiveCompleteJsonLocation}}
+	request := NewLiveRequest(Localisation{"GB", "GBP", "en-GB"}, "EDI", "LHR", "20161101", "20161103", LiveOptions{})
+	rounds := make([]*LiveProgress, 0)
+	data, err := RunLiveRequestProgress(engine, request, func(progress *LiveProgress) {
+		rounds = append(rounds, progress)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rounds))
+	assert.Equal(t, UpdatesPendingStatus, rounds[0].Status)
+	assert.Equal(t, UpdatesCompleteStatus, rounds[1].Status)
+	assert.Equal(t, 33, len(rounds[0].Agents))
+	assert.Equal(t, len(data.Itineraries), len(rounds[0].Itineraries))
+	assert.Equal(t, 0, len(rounds[1].Itineraries))
+	assert.Equal(t, "EDI", rounds[0].Itineraries[0].SearchOrigin)
+}
+
+func TestPostAndPollProgressFallback(t *testing.T) {
+	engine := &CachedEngine{GetTestSearchEngine([]string{"LHR"}), NewMemoryStore()}
+	request := NewLiveRequest(Localisation{"GB", "GBP", "en-GB"}, "LHR", "VIE", "20161101", "", LiveOptions{})
+	rounds := 0
+	for i := 0; i < 2; i++ {
+		_, err := RunLiveRequestProgress(engine, request, func(progress *LiveProgress) {
+			rounds++
+			assert.Equal(t, UpdatesCompleteStatus, progress.Status)
+			assert.Equal(t, 3, len(progress.Itineraries))
+		})
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 2, rounds)
+}
+
+func TestPostAndPollProgressWithoutCallback(t *testing.T) {
+	engine := &CachedEngine{GetTestSearchEngine([]string{"LHR"}), NewMemoryStore()}
+	request := NewLiveRequest(Localisation{"GB", "GBP", "en-GB"}, "LHR", "VIE", "20161101", "", LiveOptions{})
+	for i := 0; i < 2; i++ {
+		payload, err := engine.PostAndPollProgress(liveURL, request.Values(), nil)
+		assert.Nil(t, err)
+		assert.NotEqual(t, 0, len(payload))
+	}
+}
+
+func TestSearchProgressWithoutCallback(t *testing.T) {
+	api := &EngineSearchAPI{engine: &ReplyEngine{ReadOrPanic(LiveCompleteJsonLocation)}}
+	request := SearchRequest{
+		Localisation:  Localisation{"GB", "GBP", "en-GB"},
+		Origin:        "EDI",
+		Destinations:  []string{"LHR"},
+		DepartureDate: "20161101",
+		ReturnDate:    "20161103"}
+	results, statuses, err := api.SearchProgress(request, SearchOptions{Limit: 2}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(statuses))
+	assert.Nil(t, statuses[0].Error)
+	assert.Equal(t, 2, len(results))
+}
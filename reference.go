@@ -0,0 +1,262 @@
+package sklib
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const referenceVersionTag = "version"
+
+var referenceColumns = []string{"code", "type", "name", "city", "country", "latitude", "longitude", "timezone", "parent"}
+
+// ReferencePlace is an airport or a city of the offline reference dataset.
+// Parent is the code of the city an airport belongs to.
+type ReferencePlace struct {
+	Code      string
+	Type      string
+	Name      string
+	City      string
+	Country   string
+	Latitude  float64
+	Longitude float64
+	TimeZone  string
+	Parent    string
+}
+
+type ReferencePlaces []*ReferencePlace
+
+type ReferenceDB struct {
+	Version  string
+	cities   map[string]*ReferencePlace
+	airports map[string]*ReferencePlace
+}
+
+var (
+	defaultReference     *ReferenceDB
+	defaultReferenceLock sync.Mutex
+)
+
+func NewReferenceDB(version string) *ReferenceDB {
+	return &ReferenceDB{
+		Version:  version,
+		cities:   make(map[string]*ReferencePlace),
+		airports: make(map[string]*ReferencePlace)}
+}
+
+// DefaultReference is the embedded snapshot, unless replaced by SetDefaultReference.
+func DefaultReference() *ReferenceDB {
+	defaultReferenceLock.Lock()
+	defer defaultReferenceLock.Unlock()
+	if defaultReference == nil {
+		reference, err := LoadReference(strings.NewReader(referenceData))
+		if err != nil {
+			panic(fmt.Errorf("Invalid embedded reference data: %s", err))
+		}
+		defaultReference = reference
+	}
+	return defaultReference
+}
+
+func SetDefaultReference(reference *ReferenceDB) {
+	defaultReferenceLock.Lock()
+	defer defaultReferenceLock.Unlock()
+	defaultReference = reference
+}
+
+func ReadReferenceFile(fileName string) (*ReferenceDB, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return LoadReference(file)
+}
+
+// LoadReference reads a CSV file with a header row naming the referenceColumns,
+// optionally preceded by a "# version <version>" line.
+func LoadReference(input io.Reader) (*ReferenceDB, error) {
+	reader := bufio.NewReader(input)
+	version, err := readReferenceVersion(reader)
+	if err != nil {
+		return nil, err
+	}
+	records := csv.NewReader(reader)
+	header, err := records.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int)
+	for index, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = index
+	}
+	for _, name := range referenceColumns {
+		if _, exists := columns[name]; !exists {
+			return nil, fmt.Errorf("Missing reference column %s", name)
+		}
+	}
+
+	results := NewReferenceDB(version)
+	for {
+		record, err := records.Read()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		place, err := readReferencePlace(record, columns)
+		if err != nil {
+			return nil, err
+		}
+		if err := results.Add(place); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func readReferenceVersion(reader *bufio.Reader) (string, error) {
+	peek, err := reader.Peek(1)
+	if err == io.EOF || (err == nil && peek[0] != '#') {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	line, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	line = strings.TrimSpace(strings.TrimPrefix(line, "#"))
+	if !strings.HasPrefix(line, referenceVersionTag) {
+		return "", fmt.Errorf("Unexpected reference header %s", line)
+	}
+	return strings.TrimSpace(strings.TrimPrefix(line, referenceVersionTag)), nil
+}
+
+func readReferencePlace(record []string, columns map[string]int) (*ReferencePlace, error) {
+	get := func(name string) string {
+		return strings.TrimSpace(record[columns[name]])
+	}
+	latitude, err := strconv.ParseFloat(get("latitude"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid latitude for %s: %s", get("code"), err)
+	}
+	longitude, err := strconv.ParseFloat(get("longitude"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid longitude for %s: %s", get("code"), err)
+	}
+	return &ReferencePlace{
+			Code:      get("code"),
+			Type:      get("type"),
+			Name:      get("name"),
+			City:      get("city"),
+			Country:   get("country"),
+			Latitude:  latitude,
+			Longitude: longitude,
+			TimeZone:  get("timezone"),
+			Parent:    get("parent")},
+		nil
+}
+
+func (m *ReferenceDB) Add(place *ReferencePlace) error {
+	var places map[string]*ReferencePlace
+	switch place.Type {
+	case CityValue:
+		places = m.cities
+	case AirportValue:
+		places = m.airports
+	default:
+		return fmt.Errorf("Unknown reference place type %s for %s", place.Type, place.Code)
+	}
+	if len(place.Code) == 0 {
+		return fmt.Errorf("Missing reference place code for %s", place.Name)
+	}
+	if _, exists := places[place.Code]; exists {
+		return fmt.Errorf("Duplicate reference place %s %s", place.Type, place.Code)
+	}
+	places[place.Code] = place
+	return nil
+}
+
+func (m *ReferenceDB) FindAirport(code string) (*ReferencePlace, bool) {
+	place, exists := m.airports[code]
+	return place, exists
+}
+
+func (m *ReferenceDB) FindCity(code string) (*ReferencePlace, bool) {
+	place, exists := m.cities[code]
+	return place, exists
+}
+
+// Find looks for an airport first, then for a city.
+func (m *ReferenceDB) Find(code string) (*ReferencePlace, bool) {
+	if place, exists := m.FindAirport(code); exists {
+		return place, true
+	}
+	return m.FindCity(code)
+}
+
+// FindPlace looks a place up by its Skyscanner type, countries are not part of the dataset.
+func (m *ReferenceDB) FindPlace(placeType string, code string) (*ReferencePlace, bool) {
+	switch placeType {
+	case AirportValue, StationValue:
+		return m.FindAirport(code)
+	case CityValue:
+		return m.FindCity(code)
+	default:
+		return nil, false
+	}
+}
+
+// GetAirports lists the airports of a city, sorted by code.
+func (m *ReferenceDB) GetAirports(cityCode string) ReferencePlaces {
+	results := make(ReferencePlaces, 0)
+	for _, airport := range m.airports {
+		if airport.Parent == cityCode {
+			results = append(results, airport)
+		}
+	}
+	sort.Sort(results)
+	return results
+}
+
+// GetPlaces lists cities then airports, sorted by code.
+func (m *ReferenceDB) GetPlaces() ReferencePlaces {
+	results := make(ReferencePlaces, 0, m.Len())
+	for _, city := range m.cities {
+		results = append(results, city)
+	}
+	for _, airport := range m.airports {
+		results = append(results, airport)
+	}
+	sort.Sort(results)
+	return results
+}
+
+func (m *ReferenceDB) Len() int {
+	return len(m.cities) + len(m.airports)
+}
+
+func (slice ReferencePlaces) Len() int {
+	return len(slice)
+}
+
+func (slice ReferencePlaces) Less(i, j int) bool {
+	left := slice[i]
+	right := slice[j]
+	if left.Type == right.Type {
+		return left.Code < right.Code
+	}
+	return ComparePlaceType(left.Type, right.Type)
+}
+
+func (slice ReferencePlaces) Swap(i, j int) {
+	slice[i], slice[j] = slice[j], slice[i]
+}
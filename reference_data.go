@@ -0,0 +1,414 @@
+package sklib
+
+// referenceData is the embedded snapshot of airports and cities, in the CSV format read by LoadReference.
+const referenceData = `# version 2016-08
+code,type,name,city,country,latitude,longitude,timezone,parent
+ABZ,City,Aberdeen,Aberdeen,GB,57.20,-2.20,Europe/London,
+ACE,City,Lanzarote,Lanzarote,ES,28.95,-13.61,Atlantic/Canary,
+ADD,City,Addis Ababa,Addis Ababa,ET,8.98,38.80,Africa/Addis_Ababa,
+AGP,City,Malaga,Malaga,ES,36.67,-4.50,Europe/Madrid,
+AKL,City,Auckland,Auckland,NZ,-37.01,174.79,Pacific/Auckland,
+ALC,City,Alicante,Alicante,ES,38.28,-0.56,Europe/Madrid,
+ALG,City,Algiers,Algiers,DZ,36.69,3.22,Africa/Algiers,
+AMM,City,Amman,Amman,JO,31.72,35.99,Asia/Amman,
+AMS,City,Amsterdam,Amsterdam,NL,52.31,4.76,Europe/Amsterdam,
+ANK,City,Ankara,Ankara,TR,39.93,32.86,Europe/Istanbul,
+ATH,City,Athens,Athens,GR,37.94,23.94,Europe/Athens,
+ATL,City,Atlanta,Atlanta,US,33.64,-84.43,America/New_York,
+AUH,City,Abu Dhabi,Abu Dhabi,AE,24.43,54.65,Asia/Dubai,
+AYT,City,Antalya,Antalya,TR,36.90,30.80,Europe/Istanbul,
+BAH,City,Bahrain,Bahrain,BH,26.27,50.63,Asia/Bahrain,
+BCN,City,Barcelona,Barcelona,ES,41.30,2.08,Europe/Madrid,
+BEG,City,Belgrade,Belgrade,RS,44.82,20.29,Europe/Belgrade,
+BER,City,Berlin,Berlin,DE,52.52,13.40,Europe/Berlin,
+BEY,City,Beirut,Beirut,LB,33.82,35.49,Asia/Beirut,
+BFS,City,Belfast,Belfast,GB,54.66,-6.22,Europe/London,
+BGO,City,Bergen,Bergen,NO,60.29,5.22,Europe/Oslo,
+BHX,City,Birmingham,Birmingham,GB,52.45,-1.75,Europe/London,
+BIO,City,Bilbao,Bilbao,ES,43.30,-2.91,Europe/Madrid,
+BJS,City,Beijing,Beijing,CN,39.90,116.41,Asia/Shanghai,
+BKK,City,Bangkok,Bangkok,TH,13.76,100.50,Asia/Bangkok,
+BLL,City,Billund,Billund,DK,55.74,9.15,Europe/Copenhagen,
+BLQ,City,Bologna,Bologna,IT,44.53,11.29,Europe/Rome,
+BNE,City,Brisbane,Brisbane,AU,-27.38,153.12,Australia/Brisbane,
+BOD,City,Bordeaux,Bordeaux,FR,44.83,-0.72,Europe/Paris,
+BOG,City,Bogota,Bogota,CO,4.70,-74.15,America/Bogota,
+BOM,City,Mumbai,Mumbai,IN,19.09,72.87,Asia/Kolkata,
+BOS,City,Boston,Boston,US,42.36,-71.01,America/New_York,
+BRE,City,Bremen,Bremen,DE,53.05,8.79,Europe/Berlin,
+BRS,City,Bristol,Bristol,GB,51.38,-2.72,Europe/London,
+BRU,City,Brussels,Brussels,BE,50.85,4.35,Europe/Brussels,
+BTS,City,Bratislava,Bratislava,SK,48.17,17.21,Europe/Bratislava,
+BUD,City,Budapest,Budapest,HU,47.44,19.26,Europe/Budapest,
+BUE,City,Buenos Aires,Buenos Aires,AR,-34.60,-58.38,America/Argentina/Buenos_Aires,
+BUH,City,Bucharest,Bucharest,RO,44.43,26.10,Europe/Bucharest,
+CAI,City,Cairo,Cairo,EG,30.12,31.41,Africa/Cairo,
+CAN,City,Guangzhou,Guangzhou,CN,23.39,113.30,Asia/Shanghai,
+CAS,City,Casablanca,Casablanca,MA,33.57,-7.59,Africa/Casablanca,
+CFU,City,Corfu,Corfu,GR,39.60,19.91,Europe/Athens,
+CGN,City,Cologne,Cologne,DE,50.87,7.14,Europe/Berlin,
+CHI,City,Chicago,Chicago,US,41.88,-87.63,America/Chicago,
+CLJ,City,Cluj-Napoca,Cluj-Napoca,RO,46.79,23.69,Europe/Bucharest,
+CMB,City,Colombo,Colombo,LK,7.18,79.88,Asia/Colombo,
+CPH,City,Copenhagen,Copenhagen,DK,55.62,12.66,Europe/Copenhagen,
+CPT,City,Cape Town,Cape Town,ZA,-33.97,18.60,Africa/Johannesburg,
+CTA,City,Catania,Catania,IT,37.47,15.07,Europe/Rome,
+CUN,City,Cancun,Cancun,MX,21.04,-86.87,America/Cancun,
+DBV,City,Dubrovnik,Dubrovnik,HR,42.56,18.27,Europe/Zagreb,
+DEL,City,Delhi,Delhi,IN,28.56,77.10,Asia/Kolkata,
+DEN,City,Denver,Denver,US,39.86,-104.67,America/Denver,
+DFW,City,Dallas,Dallas,US,32.90,-97.04,America/Chicago,
+DOH,City,Doha,Doha,QA,25.27,51.61,Asia/Qatar,
+DPS,City,Denpasar,Denpasar,ID,-8.75,115.17,Asia/Makassar,
+DRS,City,Dresden,Dresden,DE,51.13,13.77,Europe/Berlin,
+DUB,City,Dublin,Dublin,IE,53.42,-6.27,Europe/Dublin,
+DUS,City,Dusseldorf,Dusseldorf,DE,51.29,6.77,Europe/Berlin,
+DXB,City,Dubai,Dubai,AE,25.20,55.27,Asia/Dubai,
+EDI,City,Edinburgh,Edinburgh,GB,55.95,-3.37,Europe/London,
+EIN,City,Eindhoven,Eindhoven,NL,51.45,5.37,Europe/Amsterdam,
+EMA,City,East Midlands,East Midlands,GB,52.83,-1.33,Europe/London,
+FAO,City,Faro,Faro,PT,37.01,-7.97,Europe/Lisbon,
+FLR,City,Florence,Florence,IT,43.81,11.20,Europe/Rome,
+FRA,City,Frankfurt,Frankfurt,DE,50.03,8.56,Europe/Berlin,
+GDN,City,Gdansk,Gdansk,PL,54.38,18.47,Europe/Warsaw,
+GLA,City,Glasgow,Glasgow,GB,55.87,-4.43,Europe/London,
+GOT,City,Gothenburg,Gothenburg,SE,57.66,12.29,Europe/Stockholm,
+GVA,City,Geneva,Geneva,CH,46.24,6.11,Europe/Zurich,
+HAJ,City,Hanover,Hanover,DE,52.46,9.69,Europe/Berlin,
+HAM,City,Hamburg,Hamburg,DE,53.63,9.99,Europe/Berlin,
+HAN,City,Hanoi,Hanoi,VN,21.22,105.81,Asia/Ho_Chi_Minh,
+HAV,City,Havana,Havana,CU,22.99,-82.41,America/Havana,
+HEL,City,Helsinki,Helsinki,FI,60.32,24.96,Europe/Helsinki,
+HER,City,Heraklion,Heraklion,GR,35.34,25.18,Europe/Athens,
+HKG,City,Hong Kong,Hong Kong,HK,22.31,113.91,Asia/Hong_Kong,
+HNL,City,Honolulu,Honolulu,US,21.32,-157.92,Pacific/Honolulu,
+HOU,City,Houston,Houston,US,29.76,-95.37,America/Chicago,
+IBZ,City,Ibiza,Ibiza,ES,38.87,1.37,Europe/Madrid,
+IEV,City,Kiev,Kiev,UA,50.45,30.52,Europe/Kiev,
+INN,City,Innsbruck,Innsbruck,AT,47.26,11.34,Europe/Vienna,
+IST,City,Istanbul,Istanbul,TR,41.01,28.98,Europe/Istanbul,
+IZM,City,Izmir,Izmir,TR,38.42,27.14,Europe/Istanbul,
+JED,City,Jeddah,Jeddah,SA,21.68,39.16,Asia/Riyadh,
+JKT,City,Jakarta,Jakarta,ID,-6.21,106.85,Asia/Jakarta,
+JNB,City,Johannesburg,Johannesburg,ZA,-26.13,28.24,Africa/Johannesburg,
+JTR,City,Santorini,Santorini,GR,36.40,25.48,Europe/Athens,
+KIV,City,Chisinau,Chisinau,MD,46.93,28.93,Europe/Chisinau,
+KRK,City,Krakow,Krakow,PL,50.08,19.78,Europe/Warsaw,
+KTM,City,Kathmandu,Kathmandu,NP,27.70,85.36,Asia/Kathmandu,
+KTW,City,Katowice,Katowice,PL,50.47,19.08,Europe/Warsaw,
+KUL,City,Kuala Lumpur,Kuala Lumpur,MY,2.75,101.71,Asia/Kuala_Lumpur,
+KWI,City,Kuwait,Kuwait,KW,29.24,47.97,Asia/Kuwait,
+LAS,City,Las Vegas,Las Vegas,US,36.08,-115.15,America/Los_Angeles,
+LAX,City,Los Angeles,Los Angeles,US,33.94,-118.41,America/Los_Angeles,
+LCA,City,Larnaca,Larnaca,CY,34.88,33.62,Asia/Nicosia,
+LED,City,St Petersburg,St Petersburg,RU,59.80,30.26,Europe/Moscow,
+LEJ,City,Leipzig,Leipzig,DE,51.42,12.24,Europe/Berlin,
+LIM,City,Lima,Lima,PE,-12.02,-77.11,America/Lima,
+LIS,City,Lisbon,Lisbon,PT,38.77,-9.13,Europe/Lisbon,
+LJU,City,Ljubljana,Ljubljana,SI,46.22,14.46,Europe/Ljubljana,
+LON,City,London,London,GB,51.51,-0.13,Europe/London,
+LOS,City,Lagos,Lagos,NG,6.58,3.32,Africa/Lagos,
+LPA,City,Gran Canaria,Gran Canaria,ES,27.93,-15.39,Atlantic/Canary,
+LPL,City,Liverpool,Liverpool,GB,53.33,-2.85,Europe/London,
+LUX,City,Luxembourg,Luxembourg,LU,49.63,6.21,Europe/Luxembourg,
+LYS,City,Lyon,Lyon,FR,45.73,5.08,Europe/Paris,
+MAD,City,Madrid,Madrid,ES,40.47,-3.56,Europe/Madrid,
+MAN,City,Manchester,Manchester,GB,53.35,-2.27,Europe/London,
+MEL,City,Melbourne,Melbourne,AU,-37.67,144.84,Australia/Melbourne,
+MEX,City,Mexico City,Mexico City,MX,19.44,-99.07,America/Mexico_City,
+MIA,City,Miami,Miami,US,25.79,-80.29,America/New_York,
+MIL,City,Milan,Milan,IT,45.46,9.19,Europe/Rome,
+MLA,City,Malta,Malta,MT,35.86,14.48,Europe/Malta,
+MLE,City,Male,Male,MV,4.19,73.53,Indian/Maldives,
+MNL,City,Manila,Manila,PH,14.51,121.02,Asia/Manila,
+MOW,City,Moscow,Moscow,RU,55.76,37.62,Europe/Moscow,
+MRS,City,Marseille,Marseille,FR,43.44,5.22,Europe/Paris,
+MSQ,City,Minsk,Minsk,BY,53.88,28.03,Europe/Minsk,
+MUC,City,Munich,Munich,DE,48.35,11.79,Europe/Berlin,
+NAP,City,Naples,Naples,IT,40.89,14.29,Europe/Rome,
+NBO,City,Nairobi,Nairobi,KE,-1.32,36.93,Africa/Nairobi,
+NCE,City,Nice,Nice,FR,43.66,7.22,Europe/Paris,
+NCL,City,Newcastle,Newcastle,GB,55.04,-1.69,Europe/London,
+NTE,City,Nantes,Nantes,FR,47.15,-1.61,Europe/Paris,
+NUE,City,Nuremberg,Nuremberg,DE,49.50,11.08,Europe/Berlin,
+NYC,City,New York,New York,US,40.71,-74.01,America/New_York,
+OPO,City,Porto,Porto,PT,41.24,-8.68,Europe/Lisbon,
+ORK,City,Cork,Cork,IE,51.84,-8.49,Europe/Dublin,
+ORL,City,Orlando,Orlando,US,28.54,-81.38,America/New_York,
+OSA,City,Osaka,Osaka,JP,34.69,135.50,Asia/Tokyo,
+OSL,City,Oslo,Oslo,NO,60.19,11.10,Europe/Oslo,
+PAR,City,Paris,Paris,FR,48.86,2.35,Europe/Paris,
+PER,City,Perth,Perth,AU,-31.94,115.97,Australia/Perth,
+PFO,City,Paphos,Paphos,CY,34.72,32.49,Asia/Nicosia,
+PHL,City,Philadelphia,Philadelphia,US,39.87,-75.24,America/New_York,
+PHX,City,Phoenix,Phoenix,US,33.43,-112.01,America/Phoenix,
+PMI,City,Palma de Mallorca,Palma de Mallorca,ES,39.55,2.74,Europe/Madrid,
+PMO,City,Palermo,Palermo,IT,38.18,13.09,Europe/Rome,
+POZ,City,Poznan,Poznan,PL,52.42,16.83,Europe/Warsaw,
+PRG,City,Prague,Prague,CZ,50.10,14.26,Europe/Prague,
+PSA,City,Pisa,Pisa,IT,43.68,10.39,Europe/Rome,
+RAK,City,Marrakech,Marrakech,MA,31.61,-8.04,Africa/Casablanca,
+REK,City,Reykjavik,Reykjavik,IS,64.15,-21.94,Atlantic/Reykjavik,
+RHO,City,Rhodes,Rhodes,GR,36.41,28.09,Europe/Athens,
+RIO,City,Rio de Janeiro,Rio de Janeiro,BR,-22.91,-43.17,America/Sao_Paulo,
+RIX,City,Riga,Riga,LV,56.92,23.97,Europe/Riga,
+ROM,City,Rome,Rome,IT,41.90,12.50,Europe/Rome,
+RTM,City,Rotterdam,Rotterdam,NL,51.96,4.44,Europe/Amsterdam,
+RUH,City,Riyadh,Riyadh,SA,24.96,46.70,Asia/Riyadh,
+SAN,City,San Diego,San Diego,US,32.73,-117.19,America/Los_Angeles,
+SAO,City,Sao Paulo,Sao Paulo,BR,-23.55,-46.63,America/Sao_Paulo,
+SCL,City,Santiago,Santiago,CL,-33.39,-70.79,America/Santiago,
+SEA,City,Seattle,Seattle,US,47.45,-122.31,America/Los_Angeles,
+SEL,City,Seoul,Seoul,KR,37.57,126.98,Asia/Seoul,
+SFO,City,San Francisco,San Francisco,US,37.62,-122.38,America/Los_Angeles,
+SGN,City,Ho Chi Minh City,Ho Chi Minh City,VN,10.82,106.65,Asia/Ho_Chi_Minh,
+SHA,City,Shanghai,Shanghai,CN,31.23,121.47,Asia/Shanghai,
+SIN,City,Singapore,Singapore,SG,1.36,103.99,Asia/Singapore,
+SKG,City,Thessaloniki,Thessaloniki,GR,40.52,22.97,Europe/Athens,
+SNN,City,Shannon,Shannon,IE,52.70,-8.92,Europe/Dublin,
+SOF,City,Sofia,Sofia,BG,42.70,23.41,Europe/Sofia,
+SPU,City,Split,Split,HR,43.54,16.30,Europe/Zagreb,
+STO,City,Stockholm,Stockholm,SE,59.33,18.07,Europe/Stockholm,
+STR,City,Stuttgart,Stuttgart,DE,48.69,9.22,Europe/Berlin,
+SVQ,City,Seville,Seville,ES,37.42,-5.89,Europe/Madrid,
+SYD,City,Sydney,Sydney,AU,-33.95,151.18,Australia/Sydney,
+SZG,City,Salzburg,Salzburg,AT,47.79,13.00,Europe/Vienna,
+TCI,City,Tenerife,Tenerife,ES,28.29,-16.63,Atlantic/Canary,
+TLL,City,Tallinn,Tallinn,EE,59.41,24.83,Europe/Tallinn,
+TLS,City,Toulouse,Toulouse,FR,43.63,1.37,Europe/Paris,
+TLV,City,Tel Aviv,Tel Aviv,IL,32.01,34.89,Asia/Jerusalem,
+TPE,City,Taipei,Taipei,TW,25.08,121.23,Asia/Taipei,
+TRN,City,Turin,Turin,IT,45.20,7.65,Europe/Rome,
+TUN,City,Tunis,Tunis,TN,36.85,10.23,Africa/Tunis,
+TYO,City,Tokyo,Tokyo,JP,35.68,139.69,Asia/Tokyo,
+VCE,City,Venice,Venice,IT,45.51,12.35,Europe/Rome,
+VIE,City,Vienna,Vienna,AT,48.11,16.57,Europe/Vienna,
+VLC,City,Valencia,Valencia,ES,39.49,-0.48,Europe/Madrid,
+VNO,City,Vilnius,Vilnius,LT,54.63,25.29,Europe/Vilnius,
+WAS,City,Washington,Washington,US,38.91,-77.04,America/New_York,
+WAW,City,Warsaw,Warsaw,PL,52.23,21.01,Europe/Warsaw,
+WRO,City,Wroclaw,Wroclaw,PL,51.10,16.89,Europe/Warsaw,
+YMQ,City,Montreal,Montreal,CA,45.50,-73.57,America/Toronto,
+YTO,City,Toronto,Toronto,CA,43.65,-79.38,America/Toronto,
+YVR,City,Vancouver,Vancouver,CA,49.19,-123.18,America/Vancouver,
+ZAG,City,Zagreb,Zagreb,HR,45.74,16.07,Europe/Zagreb,
+ZRH,City,Zurich,Zurich,CH,47.46,8.55,Europe/Zurich,
+ABZ,Airport,Aberdeen,Aberdeen,GB,57.20,-2.20,Europe/London,ABZ
+ACE,Airport,Lanzarote,Lanzarote,ES,28.95,-13.61,Atlantic/Canary,ACE
+ADB,Airport,Izmir,Izmir,TR,38.29,27.16,Europe/Istanbul,IZM
+ADD,Airport,Addis Ababa,Addis Ababa,ET,8.98,38.80,Africa/Addis_Ababa,ADD
+AGP,Airport,Malaga,Malaga,ES,36.67,-4.50,Europe/Madrid,AGP
+AKL,Airport,Auckland,Auckland,NZ,-37.01,174.79,Pacific/Auckland,AKL
+ALC,Airport,Alicante,Alicante,ES,38.28,-0.56,Europe/Madrid,ALC
+ALG,Airport,Algiers,Algiers,DZ,36.69,3.22,Africa/Algiers,ALG
+AMM,Airport,Amman Queen Alia,Amman,JO,31.72,35.99,Asia/Amman,AMM
+AMS,Airport,Amsterdam,Amsterdam,NL,52.31,4.76,Europe/Amsterdam,AMS
+ARN,Airport,Stockholm Arlanda,Stockholm,SE,59.65,17.92,Europe/Stockholm,STO
+ATH,Airport,Athens International,Athens,GR,37.94,23.94,Europe/Athens,ATH
+ATL,Airport,Atlanta Hartsfield-Jackson,Atlanta,US,33.64,-84.43,America/New_York,ATL
+AUH,Airport,Abu Dhabi,Abu Dhabi,AE,24.43,54.65,Asia/Dubai,AUH
+AYT,Airport,Antalya,Antalya,TR,36.90,30.80,Europe/Istanbul,AYT
+BAH,Airport,Bahrain,Bahrain,BH,26.27,50.63,Asia/Bahrain,BAH
+BCN,Airport,Barcelona,Barcelona,ES,41.30,2.08,Europe/Madrid,BCN
+BEG,Airport,Belgrade,Belgrade,RS,44.82,20.29,Europe/Belgrade,BEG
+BEY,Airport,Beirut,Beirut,LB,33.82,35.49,Asia/Beirut,BEY
+BFS,Airport,Belfast International,Belfast,GB,54.66,-6.22,Europe/London,BFS
+BGO,Airport,Bergen,Bergen,NO,60.29,5.22,Europe/Oslo,BGO
+BGY,Airport,Milan Bergamo,Milan,IT,45.67,9.70,Europe/Rome,MIL
+BHX,Airport,Birmingham,Birmingham,GB,52.45,-1.75,Europe/London,BHX
+BIO,Airport,Bilbao,Bilbao,ES,43.30,-2.91,Europe/Madrid,BIO
+BKK,Airport,Bangkok Suvarnabhumi,Bangkok,TH,13.69,100.75,Asia/Bangkok,BKK
+BLL,Airport,Billund,Billund,DK,55.74,9.15,Europe/Copenhagen,BLL
+BLQ,Airport,Bologna,Bologna,IT,44.53,11.29,Europe/Rome,BLQ
+BMA,Airport,Stockholm Bromma,Stockholm,SE,59.35,17.94,Europe/Stockholm,STO
+BNE,Airport,Brisbane,Brisbane,AU,-27.38,153.12,Australia/Brisbane,BNE
+BOD,Airport,Bordeaux,Bordeaux,FR,44.83,-0.72,Europe/Paris,BOD
+BOG,Airport,Bogota El Dorado,Bogota,CO,4.70,-74.15,America/Bogota,BOG
+BOM,Airport,Mumbai,Mumbai,IN,19.09,72.87,Asia/Kolkata,BOM
+BOS,Airport,Boston Logan,Boston,US,42.36,-71.01,America/New_York,BOS
+BRE,Airport,Bremen,Bremen,DE,53.05,8.79,Europe/Berlin,BRE
+BRS,Airport,Bristol,Bristol,GB,51.38,-2.72,Europe/London,BRS
+BRU,Airport,Brussels International,Brussels,BE,50.90,4.48,Europe/Brussels,BRU
+BTS,Airport,Bratislava,Bratislava,SK,48.17,17.21,Europe/Bratislava,BTS
+BUD,Airport,Budapest,Budapest,HU,47.44,19.26,Europe/Budapest,BUD
+BVA,Airport,Paris Beauvais,Paris,FR,49.45,2.11,Europe/Paris,PAR
+CAI,Airport,Cairo,Cairo,EG,30.12,31.41,Africa/Cairo,CAI
+CAN,Airport,Guangzhou Baiyun,Guangzhou,CN,23.39,113.30,Asia/Shanghai,CAN
+CDG,Airport,Paris Charles de Gaulle,Paris,FR,49.01,2.55,Europe/Paris,PAR
+CFU,Airport,Corfu,Corfu,GR,39.60,19.91,Europe/Athens,CFU
+CGK,Airport,Jakarta Soekarno-Hatta,Jakarta,ID,-6.13,106.66,Asia/Jakarta,JKT
+CGN,Airport,Cologne,Cologne,DE,50.87,7.14,Europe/Berlin,CGN
+CIA,Airport,Rome Ciampino,Rome,IT,41.80,12.59,Europe/Rome,ROM
+CLJ,Airport,Cluj-Napoca,Cluj-Napoca,RO,46.79,23.69,Europe/Bucharest,CLJ
+CMB,Airport,Colombo,Colombo,LK,7.18,79.88,Asia/Colombo,CMB
+CMN,Airport,Casablanca Mohammed V,Casablanca,MA,33.37,-7.59,Africa/Casablanca,CAS
+CPH,Airport,Copenhagen,Copenhagen,DK,55.62,12.66,Europe/Copenhagen,CPH
+CPT,Airport,Cape Town,Cape Town,ZA,-33.97,18.60,Africa/Johannesburg,CPT
+CRL,Airport,Brussels South Charleroi,Brussels,BE,50.46,4.45,Europe/Brussels,BRU
+CTA,Airport,Catania,Catania,IT,37.47,15.07,Europe/Rome,CTA
+CUN,Airport,Cancun,Cancun,MX,21.04,-86.87,America/Cancun,CUN
+DBV,Airport,Dubrovnik,Dubrovnik,HR,42.56,18.27,Europe/Zagreb,DBV
+DCA,Airport,Washington Ronald Reagan,Washington,US,38.85,-77.04,America/New_York,WAS
+DEL,Airport,Delhi Indira Gandhi,Delhi,IN,28.56,77.10,Asia/Kolkata,DEL
+DEN,Airport,Denver,Denver,US,39.86,-104.67,America/Denver,DEN
+DFW,Airport,Dallas Fort Worth,Dallas,US,32.90,-97.04,America/Chicago,DFW
+DME,Airport,Moscow Domodedovo,Moscow,RU,55.41,37.91,Europe/Moscow,MOW
+DMK,Airport,Bangkok Don Mueang,Bangkok,TH,13.91,100.61,Asia/Bangkok,BKK
+DOH,Airport,Doha,Doha,QA,25.27,51.61,Asia/Qatar,DOH
+DPS,Airport,Bali Denpasar,Denpasar,ID,-8.75,115.17,Asia/Makassar,DPS
+DRS,Airport,Dresden,Dresden,DE,51.13,13.77,Europe/Berlin,DRS
+DUB,Airport,Dublin,Dublin,IE,53.42,-6.27,Europe/Dublin,DUB
+DUS,Airport,Dusseldorf International,Dusseldorf,DE,51.29,6.77,Europe/Berlin,DUS
+DWC,Airport,Dubai World Central,Dubai,AE,24.90,55.16,Asia/Dubai,DXB
+DXB,Airport,Dubai International,Dubai,AE,25.25,55.36,Asia/Dubai,DXB
+EDI,Airport,Edinburgh,Edinburgh,GB,55.95,-3.37,Europe/London,EDI
+EIN,Airport,Eindhoven,Eindhoven,NL,51.45,5.37,Europe/Amsterdam,EIN
+EMA,Airport,East Midlands,East Midlands,GB,52.83,-1.33,Europe/London,EMA
+ESB,Airport,Ankara Esenboga,Ankara,TR,40.13,32.99,Europe/Istanbul,ANK
+EWR,Airport,New York Newark,New York,US,40.69,-74.17,America/New_York,NYC
+EZE,Airport,Buenos Aires Ezeiza,Buenos Aires,AR,-34.82,-58.54,America/Argentina/Buenos_Aires,BUE
+FAO,Airport,Faro,Faro,PT,37.01,-7.97,Europe/Lisbon,FAO
+FCO,Airport,Rome Fiumicino,Rome,IT,41.80,12.25,Europe/Rome,ROM
+FLR,Airport,Florence,Florence,IT,43.81,11.20,Europe/Rome,FLR
+FRA,Airport,Frankfurt am Main,Frankfurt,DE,50.03,8.56,Europe/Berlin,FRA
+GDN,Airport,Gdansk,Gdansk,PL,54.38,18.47,Europe/Warsaw,GDN
+GIG,Airport,Rio de Janeiro Galeao,Rio de Janeiro,BR,-22.81,-43.25,America/Sao_Paulo,RIO
+GLA,Airport,Glasgow International,Glasgow,GB,55.87,-4.43,Europe/London,GLA
+GMP,Airport,Seoul Gimpo,Seoul,KR,37.56,126.79,Asia/Seoul,SEL
+GOT,Airport,Gothenburg Landvetter,Gothenburg,SE,57.66,12.29,Europe/Stockholm,GOT
+GRU,Airport,Sao Paulo Guarulhos,Sao Paulo,BR,-23.43,-46.47,America/Sao_Paulo,SAO
+GVA,Airport,Geneva,Geneva,CH,46.24,6.11,Europe/Zurich,GVA
+HAJ,Airport,Hanover,Hanover,DE,52.46,9.69,Europe/Berlin,HAJ
+HAM,Airport,Hamburg International,Hamburg,DE,53.63,9.99,Europe/Berlin,HAM
+HAN,Airport,Hanoi,Hanoi,VN,21.22,105.81,Asia/Ho_Chi_Minh,HAN
+HAV,Airport,Havana Jose Marti,Havana,CU,22.99,-82.41,America/Havana,HAV
+HEL,Airport,Helsinki Vantaa,Helsinki,FI,60.32,24.96,Europe/Helsinki,HEL
+HER,Airport,Heraklion,Heraklion,GR,35.34,25.18,Europe/Athens,HER
+HKG,Airport,Hong Kong International,Hong Kong,HK,22.31,113.91,Asia/Hong_Kong,HKG
+HND,Airport,Tokyo Haneda,Tokyo,JP,35.55,139.78,Asia/Tokyo,TYO
+HNL,Airport,Honolulu,Honolulu,US,21.32,-157.92,Pacific/Honolulu,HNL
+IAD,Airport,Washington Dulles,Washington,US,38.95,-77.46,America/New_York,WAS
+IAH,Airport,Houston George Bush,Houston,US,29.98,-95.34,America/Chicago,HOU
+IBZ,Airport,Ibiza,Ibiza,ES,38.87,1.37,Europe/Madrid,IBZ
+ICN,Airport,Seoul Incheon,Seoul,KR,37.46,126.44,Asia/Seoul,SEL
+IEV,Airport,Kiev Zhuliany,Kiev,UA,50.40,30.45,Europe/Kiev,IEV
+INN,Airport,Innsbruck,Innsbruck,AT,47.26,11.34,Europe/Vienna,INN
+IST,Airport,Istanbul Ataturk,Istanbul,TR,40.98,28.82,Europe/Istanbul,IST
+JED,Airport,Jeddah,Jeddah,SA,21.68,39.16,Asia/Riyadh,JED
+JFK,Airport,New York John F. Kennedy,New York,US,40.64,-73.78,America/New_York,NYC
+JNB,Airport,Johannesburg OR Tambo,Johannesburg,ZA,-26.13,28.24,Africa/Johannesburg,JNB
+JTR,Airport,Santorini,Santorini,GR,36.40,25.48,Europe/Athens,JTR
+KBP,Airport,Kiev Borispol,Kiev,UA,50.35,30.89,Europe/Kiev,IEV
+KEF,Airport,Reykjavik Keflavik,Reykjavik,IS,63.99,-22.62,Atlantic/Reykjavik,REK
+KIV,Airport,Chisinau,Chisinau,MD,46.93,28.93,Europe/Chisinau,KIV
+KIX,Airport,Osaka Kansai,Osaka,JP,34.43,135.24,Asia/Tokyo,OSA
+KRK,Airport,Krakow,Krakow,PL,50.08,19.78,Europe/Warsaw,KRK
+KTM,Airport,Kathmandu,Kathmandu,NP,27.70,85.36,Asia/Kathmandu,KTM
+KTW,Airport,Katowice,Katowice,PL,50.47,19.08,Europe/Warsaw,KTW
+KUL,Airport,Kuala Lumpur International,Kuala Lumpur,MY,2.75,101.71,Asia/Kuala_Lumpur,KUL
+KWI,Airport,Kuwait,Kuwait,KW,29.24,47.97,Asia/Kuwait,KWI
+LAS,Airport,Las Vegas McCarran,Las Vegas,US,36.08,-115.15,America/Los_Angeles,LAS
+LAX,Airport,Los Angeles International,Los Angeles,US,33.94,-118.41,America/Los_Angeles,LAX
+LCA,Airport,Larnaca,Larnaca,CY,34.88,33.62,Asia/Nicosia,LCA
+LCY,Airport,London City,London,GB,51.50,0.05,Europe/London,LON
+LED,Airport,St Petersburg Pulkovo,St Petersburg,RU,59.80,30.26,Europe/Moscow,LED
+LEJ,Airport,Leipzig Halle,Leipzig,DE,51.42,12.24,Europe/Berlin,LEJ
+LGA,Airport,New York LaGuardia,New York,US,40.78,-73.87,America/New_York,NYC
+LGW,Airport,London Gatwick,London,GB,51.15,-0.19,Europe/London,LON
+LHR,Airport,London Heathrow,London,GB,51.47,-0.45,Europe/London,LON
+LIM,Airport,Lima Jorge Chavez,Lima,PE,-12.02,-77.11,America/Lima,LIM
+LIN,Airport,Milan Linate,Milan,IT,45.45,9.28,Europe/Rome,MIL
+LIS,Airport,Lisbon,Lisbon,PT,38.77,-9.13,Europe/Lisbon,LIS
+LJU,Airport,Ljubljana,Ljubljana,SI,46.22,14.46,Europe/Ljubljana,LJU
+LOS,Airport,Lagos,Lagos,NG,6.58,3.32,Africa/Lagos,LOS
+LPA,Airport,Gran Canaria,Gran Canaria,ES,27.93,-15.39,Atlantic/Canary,LPA
+LPL,Airport,Liverpool John Lennon,Liverpool,GB,53.33,-2.85,Europe/London,LPL
+LTN,Airport,London Luton,London,GB,51.87,-0.37,Europe/London,LON
+LUX,Airport,Luxembourg,Luxembourg,LU,49.63,6.21,Europe/Luxembourg,LUX
+LYS,Airport,Lyon Saint Exupery,Lyon,FR,45.73,5.08,Europe/Paris,LYS
+MAD,Airport,Madrid,Madrid,ES,40.47,-3.56,Europe/Madrid,MAD
+MAN,Airport,Manchester,Manchester,GB,53.35,-2.27,Europe/London,MAN
+MCO,Airport,Orlando International,Orlando,US,28.43,-81.31,America/New_York,ORL
+MDW,Airport,Chicago Midway,Chicago,US,41.79,-87.75,America/Chicago,CHI
+MEL,Airport,Melbourne,Melbourne,AU,-37.67,144.84,Australia/Melbourne,MEL
+MEX,Airport,Mexico City,Mexico City,MX,19.44,-99.07,America/Mexico_City,MEX
+MIA,Airport,Miami International,Miami,US,25.79,-80.29,America/New_York,MIA
+MLA,Airport,Malta,Malta,MT,35.86,14.48,Europe/Malta,MLA
+MLE,Airport,Male,Male,MV,4.19,73.53,Indian/Maldives,MLE
+MNL,Airport,Manila,Manila,PH,14.51,121.02,Asia/Manila,MNL
+MRS,Airport,Marseille Provence,Marseille,FR,43.44,5.22,Europe/Paris,MRS
+MSQ,Airport,Minsk,Minsk,BY,53.88,28.03,Europe/Minsk,MSQ
+MUC,Airport,Munich,Munich,DE,48.35,11.79,Europe/Berlin,MUC
+MXP,Airport,Milan Malpensa,Milan,IT,45.63,8.72,Europe/Rome,MIL
+NAP,Airport,Naples,Naples,IT,40.89,14.29,Europe/Rome,NAP
+NBO,Airport,Nairobi Jomo Kenyatta,Nairobi,KE,-1.32,36.93,Africa/Nairobi,NBO
+NCE,Airport,Nice,Nice,FR,43.66,7.22,Europe/Paris,NCE
+NCL,Airport,Newcastle,Newcastle,GB,55.04,-1.69,Europe/London,NCL
+NRT,Airport,Tokyo Narita,Tokyo,JP,35.77,140.39,Asia/Tokyo,TYO
+NTE,Airport,Nantes,Nantes,FR,47.15,-1.61,Europe/Paris,NTE
+NUE,Airport,Nuremberg,Nuremberg,DE,49.50,11.08,Europe/Berlin,NUE
+NYO,Airport,Stockholm Skavsta,Stockholm,SE,58.79,16.91,Europe/Stockholm,STO
+OPO,Airport,Porto,Porto,PT,41.24,-8.68,Europe/Lisbon,OPO
+ORD,Airport,Chicago O'Hare,Chicago,US,41.98,-87.90,America/Chicago,CHI
+ORK,Airport,Cork,Cork,IE,51.84,-8.49,Europe/Dublin,ORK
+ORY,Airport,Paris Orly,Paris,FR,48.72,2.38,Europe/Paris,PAR
+OSL,Airport,Oslo Gardermoen,Oslo,NO,60.19,11.10,Europe/Oslo,OSL
+OTP,Airport,Bucharest Otopeni,Bucharest,RO,44.57,26.09,Europe/Bucharest,BUH
+PEK,Airport,Beijing Capital,Beijing,CN,40.08,116.58,Asia/Shanghai,BJS
+PER,Airport,Perth,Perth,AU,-31.94,115.97,Australia/Perth,PER
+PFO,Airport,Paphos,Paphos,CY,34.72,32.49,Asia/Nicosia,PFO
+PHL,Airport,Philadelphia,Philadelphia,US,39.87,-75.24,America/New_York,PHL
+PHX,Airport,Phoenix Sky Harbor,Phoenix,US,33.43,-112.01,America/Phoenix,PHX
+PMI,Airport,Palma de Mallorca,Palma de Mallorca,ES,39.55,2.74,Europe/Madrid,PMI
+PMO,Airport,Palermo,Palermo,IT,38.18,13.09,Europe/Rome,PMO
+POZ,Airport,Poznan,Poznan,PL,52.42,16.83,Europe/Warsaw,POZ
+PRG,Airport,Prague,Prague,CZ,50.10,14.26,Europe/Prague,PRG
+PSA,Airport,Pisa,Pisa,IT,43.68,10.39,Europe/Rome,PSA
+PVG,Airport,Shanghai Pudong,Shanghai,CN,31.14,121.81,Asia/Shanghai,SHA
+RAK,Airport,Marrakech,Marrakech,MA,31.61,-8.04,Africa/Casablanca,RAK
+RHO,Airport,Rhodes,Rhodes,GR,36.41,28.09,Europe/Athens,RHO
+RIX,Airport,Riga,Riga,LV,56.92,23.97,Europe/Riga,RIX
+RTM,Airport,Rotterdam,Rotterdam,NL,51.96,4.44,Europe/Amsterdam,RTM
+RUH,Airport,Riyadh,Riyadh,SA,24.96,46.70,Asia/Riyadh,RUH
+SAN,Airport,San Diego,San Diego,US,32.73,-117.19,America/Los_Angeles,SAN
+SAW,Airport,Istanbul Sabiha Gokcen,Istanbul,TR,40.90,29.31,Europe/Istanbul,IST
+SCL,Airport,Santiago,Santiago,CL,-33.39,-70.79,America/Santiago,SCL
+SEA,Airport,Seattle Tacoma,Seattle,US,47.45,-122.31,America/Los_Angeles,SEA
+SEN,Airport,London Southend,London,GB,51.57,0.70,Europe/London,LON
+SFO,Airport,San Francisco International,San Francisco,US,37.62,-122.38,America/Los_Angeles,SFO
+SGN,Airport,Ho Chi Minh City,Ho Chi Minh City,VN,10.82,106.65,Asia/Ho_Chi_Minh,SGN
+SHA,Airport,Shanghai Hongqiao,Shanghai,CN,31.20,121.34,Asia/Shanghai,SHA
+SIN,Airport,Singapore Changi,Singapore,SG,1.36,103.99,Asia/Singapore,SIN
+SKG,Airport,Thessaloniki,Thessaloniki,GR,40.52,22.97,Europe/Athens,SKG
+SNN,Airport,Shannon,Shannon,IE,52.70,-8.92,Europe/Dublin,SNN
+SOF,Airport,Sofia,Sofia,BG,42.70,23.41,Europe/Sofia,SOF
+SPU,Airport,Split,Split,HR,43.54,16.30,Europe/Zagreb,SPU
+STN,Airport,London Stansted,London,GB,51.89,0.24,Europe/London,LON
+STR,Airport,Stuttgart,Stuttgart,DE,48.69,9.22,Europe/Berlin,STR
+SVO,Airport,Moscow Sheremetyevo,Moscow,RU,55.97,37.41,Europe/Moscow,MOW
+SVQ,Airport,Seville,Seville,ES,37.42,-5.89,Europe/Madrid,SVQ
+SXF,Airport,Berlin Schoenefeld,Berlin,DE,52.38,13.52,Europe/Berlin,BER
+SYD,Airport,Sydney Kingsford Smith,Sydney,AU,-33.95,151.18,Australia/Sydney,SYD
+SZG,Airport,Salzburg,Salzburg,AT,47.79,13.00,Europe/Vienna,SZG
+TFS,Airport,Tenerife South,Tenerife,ES,28.04,-16.57,Atlantic/Canary,TCI
+TLL,Airport,Tallinn,Tallinn,EE,59.41,24.83,Europe/Tallinn,TLL
+TLS,Airport,Toulouse Blagnac,Toulouse,FR,43.63,1.37,Europe/Paris,TLS
+TLV,Airport,Tel Aviv Ben Gurion,Tel Aviv,IL,32.01,34.89,Asia/Jerusalem,TLV
+TPE,Airport,Taipei Taoyuan,Taipei,TW,25.08,121.23,Asia/Taipei,TPE
+TRN,Airport,Turin,Turin,IT,45.20,7.65,Europe/Rome,TRN
+TUN,Airport,Tunis Carthage,Tunis,TN,36.85,10.23,Africa/Tunis,TUN
+TXL,Airport,Berlin Tegel,Berlin,DE,52.56,13.29,Europe/Berlin,BER
+VCE,Airport,Venice Marco Polo,Venice,IT,45.51,12.35,Europe/Rome,VCE
+VIE,Airport,Vienna,Vienna,AT,48.11,16.57,Europe/Vienna,VIE
+VKO,Airport,Moscow Vnukovo,Moscow,RU,55.60,37.27,Europe/Moscow,MOW
+VLC,Airport,Valencia,Valencia,ES,39.49,-0.48,Europe/Madrid,VLC
+VNO,Airport,Vilnius,Vilnius,LT,54.63,25.29,Europe/Vilnius,VNO
+WAW,Airport,Warsaw Okecie,Warsaw,PL,52.17,20.97,Europe/Warsaw,WAW
+WMI,Airport,Warsaw Modlin,Warsaw,PL,52.45,20.65,Europe/Warsaw,WAW
+WRO,Airport,Wroclaw,Wroclaw,PL,51.10,16.89,Europe/Warsaw,WRO
+YUL,Airport,Montreal Trudeau,Montreal,CA,45.47,-73.74,America/Toronto,YMQ
+YVR,Airport,Vancouver,Vancouver,CA,49.19,-123.18,America/Vancouver,YVR
+YYZ,Airport,Toronto Pearson,Toronto,CA,43.68,-79.63,America/Toronto,YTO
+ZAG,Airport,Zagreb,Zagreb,HR,45.74,16.07,Europe/Zagreb,ZAG
+ZRH,Airport,Zurich,Zurich,CH,47.46,8.55,Europe/Zurich,ZRH
+`
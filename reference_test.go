@@ -0,0 +1,87 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+const ReferenceLocation = TestDataBase + "reference.csv"
+
+func TestDefaultReference(t *testing.T) {
+	reference := DefaultReference()
+	assert.Equal(t, "2016-08", reference.Version)
+
+	gatwick, exists := reference.FindAirport("LGW")
+	assert.True(t, exists)
+	assert.Equal(t, "London Gatwick", gatwick.Name)
+	assert.Equal(t, "GB", gatwick.Country)
+	assert.Equal(t, "LON", gatwick.Parent)
+	assert.InDelta(t, 51.15, gatwick.Latitude, 1e-9)
+
+	london, exists := reference.FindCity("LON")
+	assert.True(t, exists)
+	assert.Equal(t, "Europe/London", london.TimeZone)
+	assert.Equal(t, 6, len(reference.GetAirports("LON")))
+
+	for _, place := range reference.GetPlaces() {
+		if place.Type == AirportValue {
+			_, exists := reference.FindCity(place.Parent)
+			assert.True(t, exists, place.Code)
+		}
+	}
+}
+
+func TestReadReferenceFile(t *testing.T) {
+	reference, err := ReadReferenceFile(ReferenceLocation)
+	if err != nil {
+		panic(err)
+	}
+	assert.Equal(t, "2016-09-01", reference.Version)
+	assert.Equal(t, 5, reference.Len())
+
+	airport, _ := reference.Find("VIE")
+	assert.Equal(t, AirportValue, airport.Type)
+	city, _ := reference.FindPlace(CityValue, "VIE")
+	assert.Equal(t, CityValue, city.Type)
+	_, exists := reference.FindPlace(CountryValue, "GB")
+	assert.False(t, exists)
+
+	codes := make([]string, 0)
+	for _, airport := range reference.GetAirports("LON") {
+		codes = append(codes, airport.Code)
+	}
+	assert.Equal(t, []string{"LGW", "LHR"}, codes)
+}
+
+func TestLoadReferenceErrors(t *testing.T) {
+	_, err := LoadReference(strings.NewReader("code,type,name\nLGW,Airport,Gatwick\n"))
+	assert.NotNil(t, err)
+
+	duplicate := "code,type,name,city,country,latitude,longitude,timezone,parent\n" +
+		"LGW,Airport,Gatwick,London,GB,51.15,-0.19,Europe/London,LON\n" +
+		"LGW,Airport,Gatwick,London,GB,51.15,-0.19,Europe/London,LON\n"
+	_, err = LoadReference(strings.NewReader(duplicate))
+	assert.NotNil(t, err)
+
+	reference, err := LoadReference(strings.NewReader(duplicate[:strings.LastIndex(duplicate[:len(duplicate)-1], "\n")+1]))
+	assert.Nil(t, err)
+	assert.Equal(t, "", reference.Version)
+}
+
+func TestMapPlacesWithReference(t *testing.T) {
+	reference, err := ReadReferenceFile(ReferenceLocation)
+	if err != nil {
+		panic(err)
+	}
+	places, err := MapPlacesWithReference(GetTestLiveReply().Places, reference)
+	if err != nil {
+		panic(err)
+	}
+	assert.Equal(t, "London Gatwick", places[13542].Reference.Name)
+	assert.Equal(t, "Europe/London", places[13542].Location.String())
+	// Stansted is not in this dataset but inherits the time zone of London.
+	assert.Nil(t, places[16574].Reference)
+	assert.Equal(t, "Europe/London", places[16574].GetLocation().String())
+	assert.Nil(t, places[247].Reference)
+}
@@ -0,0 +1,361 @@
+// Package remote implements sklib.SearchAPI over HTTP, against a sklib server.
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/arthurandres/sklib"
+	"github.com/arthurandres/sklib/server"
+)
+
+const (
+	defaultTimeout    = 2 * time.Minute
+	defaultRetries    = 2
+	defaultRetryDelay = 500 * time.Millisecond
+	jsonContentType   = "application/json"
+	eventPrefix       = "event: "
+	dataPrefix        = "data: "
+)
+
+// Client is a drop-in replacement for sklib.EngineSearchAPI, running the calls on a sklib server.
+// Timeout bounds each call, retries included, and 0 means no timeout.
+// Calls are retried Retries times, waiting RetryDelay, doubled on each attempt, in between.
+// GET calls are retried on network errors and on 502, 503 and 504 replies. Searches are only
+// retried when the connection failed: after an error reply the server may still be searching,
+// and each attempt would start new live sessions against the rate-limited API.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	Timeout    time.Duration
+	Retries    int
+	RetryDelay time.Duration
+}
+
+// Error is an error reply of the server, other than an invalid request.
+// StatusCode is 0 for errors sent in an event stream.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (m *Error) Error() string {
+	if m.StatusCode == 0 {
+		return m.Message
+	}
+	return fmt.Sprintf("Server replied %d: %s", m.StatusCode, m.Message)
+}
+
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: http.DefaultClient,
+		Timeout:    defaultTimeout,
+		Retries:    defaultRetries,
+		RetryDelay: defaultRetryDelay}
+}
+
+func (m *Client) Browse(request sklib.BrowseRoutesRequest) (sklib.FullQuotes, error) {
+	query := localisationQuery(request.Localisation)
+	query.Set("origin", request.Origin)
+	query.Set("departure", request.DepartureDate)
+	query.Set("return", request.ReturnDate)
+	var reply sklib.FullQuotes
+	return reply, m.call(http.MethodGet, "/browse", query, nil, &reply)
+}
+
+func (m *Client) Search(request sklib.SearchRequest, options sklib.SearchOptions) (sklib.Itineraries, error) {
+	var reply sklib.Itineraries
+	return reply, m.call(http.MethodPost, "/search", nil, server.SearchBody{Request: request, Options: options}, &reply)
+}
+
+func (m *Client) ListLocales() ([]sklib.LocaleDto, error) {
+	var reply []sklib.LocaleDto
+	return reply, m.call(http.MethodGet, "/locales", nil, nil, &reply)
+}
+
+func (m *Client) ListCurrencies() ([]sklib.CurrencyDto, error) {
+	var reply []sklib.CurrencyDto
+	return reply, m.call(http.MethodGet, "/currencies", nil, nil, &reply)
+}
+
+func (m *Client) ListCountries(locale string) ([]sklib.CountryDto, error) {
+	var reply []sklib.CountryDto
+	return reply, m.call(http.MethodGet, "/countries", url.Values{"locale": {locale}}, nil, &reply)
+}
+
+func (m *Client) Autosuggest(localisation sklib.Localisation, query string) (sklib.PlaceSuggestions, error) {
+	values := localisationQuery(localisation)
+	values.Set("query", query)
+	var reply sklib.PlaceSuggestions
+	return reply, m.call(http.MethodGet, "/autosuggest", values, nil, &reply)
+}
+
+func (m *Client) Overview(request sklib.OverviewRequest) (*sklib.OverviewReply, error) {
+	var reply sklib.OverviewReply
+	if err := m.call(http.MethodPost, "/overview", nil, request, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (m *Client) Details(request sklib.DetailsRequest) (*sklib.DetailsReply, error) {
+	var reply server.DetailsReply
+	if err := m.call(http.MethodPost, "/details", nil, request, &reply); err != nil {
+		return nil, err
+	}
+	return &sklib.DetailsReply{Itineraries: reply.Itineraries, Statuses: readStatuses(reply.Statuses)}, nil
+}
+
+// SearchProgress reads the event stream of the search, reporting a LiveProgress per poll round like
+// sklib.EngineSearchAPI does. The result is the one of the done event, as the server already
+// applied the options to the itineraries of the last round.
+func (m *Client) SearchProgress(request sklib.SearchRequest, options sklib.SearchOptions, progress sklib.LiveProgressCallback) (sklib.Itineraries, []sklib.SearchStatus, error) {
+	ctx, cancel := m.context()
+	defer cancel()
+	response, err := m.send(ctx, http.MethodPost, "/search/stream", nil, server.SearchBody{Request: request, Options: options})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+	reader := &streamReader{progress: progress}
+	if err := readEvents(response.Body, reader.event); err != nil {
+		return nil, nil, err
+	}
+	if !reader.done {
+		return nil, nil, errors.New("Stream ended before the search completed")
+	}
+	return reader.results, reader.statuses, nil
+}
+
+func localisationQuery(localisation sklib.Localisation) url.Values {
+	return url.Values{
+		"country":  {localisation.Country},
+		"currency": {localisation.Currency},
+		"locale":   {localisation.Language}}
+}
+
+func (m *Client) context() (context.Context, context.CancelFunc) {
+	if m.Timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), m.Timeout)
+}
+
+func (m *Client) call(method string, path string, query url.Values, body interface{}, reply interface{}) error {
+	ctx, cancel := m.context()
+	defer cancel()
+	response, err := m.send(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	return json.NewDecoder(response.Body).Decode(reply)
+}
+
+// send returns the response once the server replied 200, the caller closes its body.
+func (m *Client) send(ctx context.Context, method string, path string, query url.Values, body interface{}) (*http.Response, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+	target := m.BaseURL + path
+	if len(query) != 0 {
+		target += "?" + query.Encode()
+	}
+	delay := m.RetryDelay
+	for attempt := 0; ; attempt++ {
+		response, err := m.attempt(ctx, method, target, payload)
+		if err == nil || attempt >= m.Retries || !isRetryable(err, method == http.MethodGet) {
+			return response, err
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, err
+		}
+		delay *= 2
+	}
+}
+
+func (m *Client) attempt(ctx context.Context, method string, target string, payload []byte) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		request.Header.Set("Content-Type", jsonContentType)
+	}
+	response, err := m.HTTPClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, decodeError(response)
+	}
+	return response, nil
+}
+
+// isRetryable always retries failed connections, as the server never received the request,
+// the other errors only for idempotent calls.
+func isRetryable(err error, idempotent bool) bool {
+	var connection *net.OpError
+	if errors.As(err, &connection) && connection.Op == "dial" {
+		return true
+	}
+	if !idempotent {
+		return false
+	}
+	var replied *Error
+	if errors.As(err, &replied) {
+		switch replied.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	var transport *url.Error
+	return errors.As(err, &transport)
+}
+
+func decodeError(response *http.Response) error {
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return &Error{response.StatusCode, err.Error()}
+	}
+	var reply server.ErrorReply
+	if err := json.Unmarshal(data, &reply); err != nil || len(reply.Error) == 0 {
+		return &Error{response.StatusCode, http.StatusText(response.StatusCode)}
+	}
+	return replyError(response.StatusCode, reply)
+}
+
+// replyError rebuilds the sklib errors of invalid requests, so callers can handle them as in process.
+func replyError(statusCode int, reply server.ErrorReply) error {
+	switch {
+	case len(reply.Field) != 0 && (len(reply.Value) != 0 || len(reply.Suggestions) != 0):
+		return &sklib.LocalisationError{Field: reply.Field, Value: reply.Value, Suggestions: reply.Suggestions}
+	case len(reply.Field) != 0:
+		return &sklib.ValidationError{Field: reply.Field, Message: reply.Message}
+	default:
+		return &Error{statusCode, reply.Error}
+	}
+}
+
+func readStatuses(replies []server.StatusReply) []sklib.SearchStatus {
+	results := make([]sklib.SearchStatus, len(replies))
+	for index, reply := range replies {
+		results[index] = sklib.SearchStatus{
+			Origin:      reply.Origin,
+			Destination: reply.Destination,
+			Itineraries: reply.Itineraries,
+			Duration:    reply.Duration}
+		if len(reply.Error) != 0 {
+			results[index].Error = errors.New(reply.Error)
+		}
+	}
+	return results
+}
+
+// readEvents calls handle with every event of the stream until handle or the stream stops,
+// events can be large so lines are not capped.
+func readEvents(stream io.Reader, handle func(name string, data []byte) (bool, error)) error {
+	reader := bufio.NewReader(stream)
+	name := ""
+	var data []byte
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.HasPrefix(line, eventPrefix):
+			name = strings.TrimPrefix(line, eventPrefix)
+		case strings.HasPrefix(line, dataPrefix):
+			data = append(data, strings.TrimPrefix(line, dataPrefix)...)
+		case len(line) == 0 && len(name) != 0:
+			more, handleErr := handle(name, data)
+			if handleErr != nil || !more {
+				return handleErr
+			}
+			name, data = "", nil
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+// streamReader merges the status and itineraries events of a round back into a LiveProgress.
+type streamReader struct {
+	progress sklib.LiveProgressCallback
+	pending  *sklib.LiveProgress
+	results  sklib.Itineraries
+	statuses []sklib.SearchStatus
+	done     bool
+}
+
+func (m *streamReader) flush() {
+	if m.pending != nil && m.progress != nil {
+		m.progress(m.pending)
+	}
+	m.pending = nil
+}
+
+func (m *streamReader) event(name string, data []byte) (bool, error) {
+	switch name {
+	case server.EventStatus:
+		var event server.StatusEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return false, err
+		}
+		m.flush()
+		m.pending = &sklib.LiveProgress{
+			Origin:      event.Origin,
+			Destination: event.Destination,
+			Status:      event.Status,
+			Agents:      event.Agents,
+			Itineraries: make(sklib.Itineraries, 0)}
+	case server.EventItineraries:
+		var event server.ItinerariesEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return false, err
+		}
+		if m.pending != nil {
+			m.pending.Itineraries = event.Itineraries
+		}
+	case server.EventDone:
+		var event server.DoneEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return false, err
+		}
+		m.flush()
+		m.results = event.Itineraries
+		m.statuses = readStatuses(event.Statuses)
+		m.done = true
+		return false, nil
+	case server.EventError:
+		var reply server.ErrorReply
+		if err := json.Unmarshal(data, &reply); err != nil {
+			return false, err
+		}
+		m.flush()
+		return false, replyError(0, reply)
+	}
+	return true, nil
+}
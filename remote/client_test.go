@@ -0,0 +1,224 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/arthurandres/sklib"
+	"github.com/arthurandres/sklib/server"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ sklib.SearchAPI = &Client{}
+
+func newTestClient(api sklib.SearchAPI) (*Client, func()) {
+	httpServer := httptest.NewServer(server.NewServer(api, time.Second))
+	client := NewClient(httpServer.URL + "/")
+	client.RetryDelay = time.Millisecond
+	return client, httpServer.Close
+}
+
+func TestBrowse(t *testing.T) {
+	var received sklib.BrowseRoutesRequest
+	api := &sklib.MockSearchAPI{BrowseFunc: func(request sklib.BrowseRoutesRequest) (sklib.FullQuotes, error) {
+		received = request
+		return sklib.FullQuotes{{Quote: sklib.QuoteDto{QuoteId: 1, MinPrice: sklib.NewDecimal(25)}}}, nil
+	}}
+	client, stop := newTestClient(api)
+	defer stop()
+	request := sklib.NewBrowseRouteRequest(sklib.Localisation{Country: "GB", Currency: "GBP", Language: "en-GB"}, "LOND", "2016-08-19", "2016-08-21")
+	quotes, err := client.Browse(request)
+	assert.Nil(t, err)
+	assert.Equal(t, request, received)
+	assert.Equal(t, 1, len(quotes))
+	assert.Equal(t, sklib.NewDecimal(25), quotes[0].Quote.MinPrice)
+}
+
+func TestValidationErrors(t *testing.T) {
+	api := &sklib.MockSearchAPI{
+		ListCountriesFunc: func(locale string) ([]sklib.CountryDto, error) {
+			return nil, &sklib.ValidationError{Field: "Locale", Message: "missing"}
+		},
+		AutosuggestFunc: func(localisation sklib.Localisation, query string) (sklib.PlaceSuggestions, error) {
+			return nil, &sklib.LocalisationError{Field: "Currency", Value: "GPB", Suggestions: []string{"GBP"}}
+		}}
+	client, stop := newTestClient(api)
+	defer stop()
+
+	_, err := client.ListCountries("")
+	assert.Equal(t, &sklib.ValidationError{Field: "Locale", Message: "missing"}, err)
+
+	_, err = client.Autosuggest(sklib.Localisation{Country: "GB", Currency: "GPB", Language: "en-GB"}, "paris")
+	assert.Equal(t, &sklib.LocalisationError{Field: "Currency", Value: "GPB", Suggestions: []string{"GBP"}}, err)
+
+	_, err = client.ListLocales()
+	assert.Equal(t, &Error{http.StatusBadGateway, "Not implemented: ListLocales"}, err)
+}
+
+func TestRetries(t *testing.T) {
+	calls := 0
+	httpServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		calls++
+		if calls < 3 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writer.Write([]byte(`[{"Code": "GBP"}]`))
+	}))
+	defer httpServer.Close()
+	client := NewClient(httpServer.URL)
+	client.RetryDelay = time.Millisecond
+
+	currencies, err := client.ListCurrencies()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, "GBP", currencies[0].Code)
+
+	calls = 0
+	client.Retries = 1
+	_, err = client.ListCurrencies()
+	assert.Equal(t, &Error{http.StatusServiceUnavailable, "Service Unavailable"}, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestSearchRetries(t *testing.T) {
+	calls := 0
+	httpServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		calls++
+		writer.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer httpServer.Close()
+	client := NewClient(httpServer.URL)
+	client.RetryDelay = time.Millisecond
+	request := sklib.SearchRequest{Origin: "LOND", Destinations: []string{"PARI"}}
+	_, err := client.Search(request, sklib.SearchOptions{})
+	assert.Equal(t, &Error{http.StatusGatewayTimeout, "Gateway Timeout"}, err)
+	assert.Equal(t, 1, calls)
+
+	dials := 0
+	client.HTTPClient = &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network string, address string) (net.Conn, error) {
+			dials++
+			return nil, &net.OpError{Op: "dial", Net: network, Err: errors.New("connection refused")}
+		}}}
+	_, err = client.Search(request, sklib.SearchOptions{})
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, dials)
+}
+
+func TestTimeout(t *testing.T) {
+	api := &sklib.MockSearchAPI{ListLocalesFunc: func() ([]sklib.LocaleDto, error) {
+		time.Sleep(200 * time.Millisecond)
+		return nil, nil
+	}}
+	client, stop := newTestClient(api)
+	defer stop()
+	client.Timeout = 20 * time.Millisecond
+	start := time.Now()
+	_, err := client.ListLocales()
+	assert.NotNil(t, err)
+	assert.True(t, time.Since(start) < 150*time.Millisecond)
+}
+
+func TestDetails(t *testing.T) {
+	api := &sklib.MockSearchAPI{DetailsFunc: func(request sklib.DetailsRequest) (*sklib.DetailsReply, error) {
+		return &sklib.DetailsReply{
+			Itineraries: sklib.Itineraries{},
+			Statuses:    []sklib.SearchStatus{{Origin: "LOND", Destination: "PARI", Error: errors.New("Failed")}}}, nil
+	}}
+	client, stop := newTestClient(api)
+	defer stop()
+	reply, err := client.Details(sklib.DetailsRequest{Origin: "LOND", Destinations: []string{"PARI"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "PARI", reply.Statuses[0].Destination)
+	assert.Equal(t, errors.New("Failed"), reply.Statuses[0].Error)
+}
+
+func TestSearchProgress(t *testing.T) {
+	api := &sklib.MockSearchAPI{SearchProgressFunc: func(request sklib.SearchRequest, options sklib.SearchOptions, progress sklib.LiveProgressCallback) (sklib.Itineraries, []sklib.SearchStatus, error) {
+		assert.Equal(t, 2, options.Limit)
+		progress(&sklib.LiveProgress{Origin: "LOND", Destination: "PARI", Status: sklib.UpdatesPendingStatus})
+		progress(&sklib.LiveProgress{Origin: "LOND", Destination: "PARI", Status: sklib.UpdatesCompleteStatus,
+			Itineraries: sklib.Itineraries{{SearchOrigin: "LOND"}, {SearchOrigin: "LOND"}, {SearchOrigin: "LOND"}}})
+		return sklib.Itineraries{{SearchOrigin: "LOND"}, {SearchOrigin: "LOND"}}, []sklib.SearchStatus{{Origin: "LOND", Destination: "PARI", Itineraries: 3}}, nil
+	}}
+	client, stop := newTestClient(api)
+	defer stop()
+	rounds := make([]*sklib.LiveProgress, 0)
+	request := sklib.SearchRequest{Origin: "LOND", Destinations: []string{"PARI"}}
+	results, statuses, err := client.SearchProgress(request, sklib.SearchOptions{Limit: 2}, func(progress *sklib.LiveProgress) {
+		rounds = append(rounds, progress)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rounds))
+	assert.Equal(t, sklib.UpdatesPendingStatus, rounds[0].Status)
+	assert.Equal(t, 0, len(rounds[0].Itineraries))
+	assert.Equal(t, 3, len(rounds[1].Itineraries))
+	assert.Equal(t, 2, len(results))
+	assert.Equal(t, 3, statuses[0].Itineraries)
+}
+
+func TestSearchProgressError(t *testing.T) {
+	client, stop := newTestClient(&sklib.MockSearchAPI{})
+	defer stop()
+	request := sklib.SearchRequest{Origin: "LOND", Destinations: []string{"PARI"}}
+	_, _, err := client.SearchProgress(request, sklib.SearchOptions{}, nil)
+	assert.Equal(t, &Error{0, "Not implemented: SearchProgress"}, err)
+}
+
+func TestSearchProgressRepriced(t *testing.T) {
+	api := &sklib.MockSearchAPI{SearchProgressFunc: func(request sklib.SearchRequest, options sklib.SearchOptions, progress sklib.LiveProgressCallback) (sklib.Itineraries, []sklib.SearchStatus, error) {
+		first := &sklib.Itinerary{PricingOptions: sklib.PricingOptions{{Price: sklib.NewDecimal(100)}}}
+		progress(&sklib.LiveProgress{Origin: "LOND", Destination: "PARI", Status: sklib.UpdatesPendingStatus, Itineraries: sklib.Itineraries{first}})
+		// The second round re-prices the itinerary, which progress only sends the first time.
+		progress(&sklib.LiveProgress{Origin: "LOND", Destination: "PARI", Status: sklib.UpdatesCompleteStatus})
+		final := &sklib.Itinerary{PricingOptions: sklib.PricingOptions{{Price: sklib.NewDecimal(80)}}}
+		return sklib.Itineraries{final}, []sklib.SearchStatus{{Origin: "LOND", Destination: "PARI", Itineraries: 1}}, nil
+	}}
+	client, stop := newTestClient(api)
+	defer stop()
+	request := sklib.SearchRequest{Origin: "LOND", Destinations: []string{"PARI"}}
+	results, _, err := client.SearchProgress(request, sklib.SearchOptions{}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, sklib.NewDecimal(80), results[0].GetPrice())
+}
+
+func TestSearchLocalTimes(t *testing.T) {
+	paris, err := sklib.LoadTimeZone("Europe/Paris")
+	assert.Nil(t, err)
+	itinerary := &sklib.Itinerary{OutboundLeg: &sklib.Leg{
+		Origin:      &sklib.Place{Code: "CDG", Type: "Airport", Reference: &sklib.ReferencePlace{Code: "CDG", TimeZone: "Europe/Paris"}},
+		Destination: &sklib.Place{Code: "LHR", Type: "Airport"},
+		Departure:   time.Date(2016, 11, 1, 8, 30, 0, 0, paris)}}
+	api := &sklib.MockSearchAPI{
+		SearchFunc: func(request sklib.SearchRequest, options sklib.SearchOptions) (sklib.Itineraries, error) {
+			return sklib.Itineraries{itinerary}, nil
+		},
+		SearchProgressFunc: func(request sklib.SearchRequest, options sklib.SearchOptions, progress sklib.LiveProgressCallback) (sklib.Itineraries, []sklib.SearchStatus, error) {
+			progress(&sklib.LiveProgress{Origin: "PARI", Destination: "LOND", Status: sklib.UpdatesCompleteStatus, Itineraries: sklib.Itineraries{itinerary}})
+			return sklib.Itineraries{itinerary}, nil, nil
+		}}
+	client, stop := newTestClient(api)
+	defer stop()
+	request := sklib.SearchRequest{Origin: "PARI", Destinations: []string{"LOND"}}
+	after := 8 * time.Hour
+	options := sklib.SearchOptions{OutboundDepartAfter: &after}
+
+	results, _, err := client.SearchProgress(request, options, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, "08:30", results[0].OutboundLeg.GetLocalDeparture().Format("15:04"))
+
+	results, err = client.Search(request, options)
+	assert.Nil(t, err)
+	assert.Equal(t, paris, results[0].OutboundLeg.Origin.Location)
+	london, _ := sklib.FindLocation("LHR")
+	assert.Equal(t, london, results[0].OutboundLeg.Destination.Location)
+	assert.Equal(t, 1, len(options.Apply(results)))
+}
@@ -1,13 +1,141 @@
 package sklib
 
+import (
+	"sync"
+	"time"
+)
+
+const referenceTTL = 7 * 24 * time.Hour
+
 type SearchAPI interface {
 	Browse(request BrowseRoutesRequest) (FullQuotes, error)
+	Search(request SearchRequest, options SearchOptions) (Itineraries, error)
+	SearchProgress(request SearchRequest, options SearchOptions, progress LiveProgressCallback) (Itineraries, []SearchStatus, error)
+	ListLocales() ([]LocaleDto, error)
+	ListCurrencies() ([]CurrencyDto, error)
+	ListCountries(locale string) ([]CountryDto, error)
+	Autosuggest(localisation Localisation, query string) (PlaceSuggestions, error)
+	Overview(request OverviewRequest) (*OverviewReply, error)
+	Details(request DetailsRequest) (*DetailsReply, error)
 }
 
+// EngineSearchAPI fills the localisation fields requests leave empty with its own,
+// and their concurrency when 0. Reference data is kept for a week unless configured otherwise.
 type EngineSearchAPI struct {
-	engine RequestEngine
+	engine          RequestEngine
+	localisation    Localisation
+	concurrency     int
+	referenceTTL    time.Duration
+	referenceEngine RequestEngine
+	referenceOnce   sync.Once
+}
+
+func NewEngineSearchAPI(engine RequestEngine) *EngineSearchAPI {
+	return &EngineSearchAPI{engine: engine}
 }
 
 func (m *EngineSearchAPI) Browse(request BrowseRoutesRequest) (FullQuotes, error) {
+	request.Localisation = m.localise(request.Localisation)
 	return Browse(m.engine, request)
 }
+
+func (m *EngineSearchAPI) Search(request SearchRequest, options SearchOptions) (Itineraries, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	results, err := Search(m.engine, m.searchRequest(request))
+	if err != nil {
+		return nil, err
+	}
+	return options.Apply(results), nil
+}
+
+// SearchProgress filters the itineraries of each poll round with the options,
+// sorting and truncating only apply to the final results.
+func (m *EngineSearchAPI) SearchProgress(request SearchRequest, options SearchOptions, progress LiveProgressCallback) (Itineraries, []SearchStatus, error) {
+	if err := options.Validate(); err != nil {
+		return nil, nil, err
+	}
+	var filtered LiveProgressCallback
+	if progress != nil {
+		filter := options.Filter()
+		filtered = func(round *LiveProgress) {
+			round.Itineraries = ApplyFilter(round.Itineraries, filter)
+			progress(round)
+		}
+	}
+	results, statuses, err := SearchWithProgress(m.engine, m.searchRequest(request), filtered)
+	if err != nil {
+		return nil, nil, err
+	}
+	return options.Apply(results), statuses, nil
+}
+
+func (m *EngineSearchAPI) ListLocales() ([]LocaleDto, error) {
+	return ListLocales(m.getReferenceEngine())
+}
+
+func (m *EngineSearchAPI) ListCurrencies() ([]CurrencyDto, error) {
+	return ListCurrencies(m.getReferenceEngine())
+}
+
+func (m *EngineSearchAPI) ListCountries(locale string) ([]CountryDto, error) {
+	return ListCountries(m.getReferenceEngine(), locale)
+}
+
+func (m *EngineSearchAPI) Autosuggest(localisation Localisation, query string) (PlaceSuggestions, error) {
+	return Autosuggest(m.engine, m.localise(localisation), query)
+}
+
+// FetchLocalisationData gets fresh reference data, to build a LocalisationValidator.
+func (m *EngineSearchAPI) FetchLocalisationData(locale string) (LocalisationData, error) {
+	locales, err := m.ListLocales()
+	if err != nil {
+		return LocalisationData{}, err
+	}
+	currencies, err := m.ListCurrencies()
+	if err != nil {
+		return LocalisationData{}, err
+	}
+	countries, err := m.ListCountries(locale)
+	if err != nil {
+		return LocalisationData{}, err
+	}
+	return LocalisationData{locales, currencies, countries}, nil
+}
+
+// getReferenceEngine keeps reference data in memory, it seldom changes.
+// Once the TTL expires the data is fetched again from m.engine, which may have cached it too.
+func (m *EngineSearchAPI) getReferenceEngine() RequestEngine {
+	m.referenceOnce.Do(func() {
+		ttl := m.referenceTTL
+		if ttl <= 0 {
+			ttl = referenceTTL
+		}
+		m.referenceEngine = &CachedEngine{
+			Engine: m.engine,
+			Cache:  &ExpiringStore{Store: NewMemoryStore(), TTL: ttl}}
+	})
+	return m.referenceEngine
+}
+
+func (m *EngineSearchAPI) localise(localisation Localisation) Localisation {
+	if len(localisation.Country) == 0 {
+		localisation.Country = m.localisation.Country
+	}
+	if len(localisation.Currency) == 0 {
+		localisation.Currency = m.localisation.Currency
+	}
+	if len(localisation.Language) == 0 {
+		localisation.Language = m.localisation.Language
+	}
+	return localisation
+}
+
+func (m *EngineSearchAPI) searchRequest(request SearchRequest) SearchRequest {
+	request.Localisation = m.localise(request.Localisation)
+	if request.Concurrency == 0 {
+		request.Concurrency = m.concurrency
+	}
+	return request
+}
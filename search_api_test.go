@@ -0,0 +1,69 @@
+package sklib
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// TestEngine serves files from testdata and counts the requests it receives.
+type TestEngine struct {
+	Files map[string]string
+	Calls map[string]int
+	lock  sync.Mutex
+}
+
+func NewTestEngine(files map[string]string) *TestEngine {
+	return &TestEngine{Files: files, Calls: make(map[string]int)}
+}
+
+func (m *TestEngine) Get(url string) ([]byte, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.Calls[url]++
+	fileName, exists := m.Files[url]
+	if !exists {
+		return nil, fmt.Errorf("Unexpected request %s", url)
+	}
+	return ReadOrPanic(fileName), nil
+}
+
+func (m *TestEngine) PostAndPoll(url string, form url.Values) ([]byte, error) {
+	return m.Get(url + "?" + form.Encode())
+}
+
+func GetTestReferenceEngine() *TestEngine {
+	return NewTestEngine(map[string]string{
+		localesURL:                            LocalesLocation,
+		currenciesURL:                         CurrenciesLocation,
+		fmt.Sprintf(countriesFormat, "en-GB"): CountriesLocation})
+}
+
+func TestListReferenceData(t *testing.T) {
+	engine := GetTestReferenceEngine()
+	api := &EngineSearchAPI{engine: engine}
+
+	locales, err := api.ListLocales()
+	assert.Nil(t, err)
+	assert.Equal(t, 43, len(locales))
+
+	currencies, err := api.ListCurrencies()
+	assert.Nil(t, err)
+	assert.Equal(t, 153, len(currencies))
+
+	countries, err := api.ListCountries("en-GB")
+	assert.Nil(t, err)
+	assert.Equal(t, 234, len(countries))
+
+	_, err = api.ListCountries("")
+	assert.NotNil(t, err)
+
+	api.ListLocales()
+	api.ListCurrencies()
+	api.ListCountries("en-GB")
+	for url, calls := range engine.Calls {
+		assert.Equal(t, 1, calls, url)
+	}
+}
@@ -0,0 +1,78 @@
+package sklib
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	SortByPrice     = "price"
+	SortByDeparture = "departure"
+	SortByDuration  = "duration"
+)
+
+// SearchOptions filter and sort live search results, the zero value keeps every itinerary cheapest first.
+// The time limits are times of day at the departure airport.
+type SearchOptions struct {
+	DirectOnly           bool
+	OutboundDepartAfter  *time.Duration `json:",omitempty"`
+	OutboundDepartBefore *time.Duration `json:",omitempty"`
+	InboundDepartAfter   *time.Duration `json:",omitempty"`
+	InboundDepartBefore  *time.Duration `json:",omitempty"`
+	SortBy               string         `json:",omitempty"`
+	Limit                int            `json:",omitempty"`
+}
+
+func (m *SearchOptions) Validate() error {
+	switch m.SortBy {
+	case "", SortByPrice, SortByDeparture, SortByDuration:
+	default:
+		return &ValidationError{"SortBy", fmt.Sprintf("unknown sort %s", m.SortBy)}
+	}
+	if m.Limit < 0 {
+		return &ValidationError{"Limit", "cannot be negative"}
+	}
+	return nil
+}
+
+func (m *SearchOptions) Filter() CompositeFilter {
+	filter := make(CompositeFilter, 0)
+	if m.DirectOnly {
+		filter = filter.AppendDirectOnly()
+	}
+	filter = filter.AppendTimeFilter(m.OutboundDepartAfter, false, true)
+	filter = filter.AppendTimeFilter(m.OutboundDepartBefore, true, true)
+	filter = filter.AppendTimeFilter(m.InboundDepartAfter, false, false)
+	return filter.AppendTimeFilter(m.InboundDepartBefore, true, false)
+}
+
+// Apply filters, sorts then truncates the itineraries.
+func (m *SearchOptions) Apply(input Itineraries) Itineraries {
+	results := ApplyFilter(input, m.Filter())
+	switch m.SortBy {
+	case SortByDeparture:
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].OutboundLeg.Departure.Before(results[j].OutboundLeg.Departure)
+		})
+	case SortByDuration:
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].GetDuration() < results[j].GetDuration()
+		})
+	default:
+		sort.Stable(results)
+	}
+	if m.Limit > 0 && len(results) > m.Limit {
+		results = results[:m.Limit]
+	}
+	return results
+}
+
+// GetDuration is the time spent travelling, on both legs of a return trip.
+func (m *Itinerary) GetDuration() time.Duration {
+	var result time.Duration
+	for _, leg := range m.GetLegs() {
+		result += leg.Duration
+	}
+	return result
+}
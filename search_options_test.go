@@ -0,0 +1,68 @@
+package sklib
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var (
+	_ SearchAPI = &EngineSearchAPI{}
+	_ SearchAPI = &MockSearchAPI{}
+)
+
+func GetTestOneWaySearchRequest() SearchRequest {
+	return SearchRequest{
+		Localisation:  Localisation{"GB", "GBP", "en-GB"},
+		Origin:        "LGW",
+		Destinations:  []string{"VIE"},
+		DepartureDate: "20161101"}
+}
+
+func TestSearchOptions(t *testing.T) {
+	api := NewEngineSearchAPI(GetTestSearchEngine([]string{"LGW"}))
+	request := GetTestOneWaySearchRequest()
+
+	all, err := api.Search(request, SearchOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(all))
+	assert.Equal(t, ParseDecimalOP("39.82"), all[0].GetPrice())
+
+	directs, err := api.Search(request, SearchOptions{DirectOnly: true, Limit: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(directs))
+	assert.True(t, directs[0].OutboundLeg.IsDirect())
+
+	after := 8 * time.Hour
+	morning, err := api.Search(request, SearchOptions{OutboundDepartAfter: &after, SortBy: SortByDeparture})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(morning))
+	assert.True(t, morning[0].OutboundLeg.Departure.Before(morning[1].OutboundLeg.Departure))
+
+	_, err = api.Search(request, SearchOptions{SortBy: "carrier"})
+	assert.Equal(t, "SortBy", err.(*ValidationError).Field)
+}
+
+func TestSearchOptionsJson(t *testing.T) {
+	before := 18 * time.Hour
+	options := SearchOptions{DirectOnly: true, InboundDepartBefore: &before, SortBy: SortByDuration, Limit: 5}
+	data, err := json.Marshal(options)
+	assert.Nil(t, err)
+	var decoded SearchOptions
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, options, decoded)
+	assert.Equal(t, 2, len(decoded.Filter()))
+}
+
+func TestMockSearchAPI(t *testing.T) {
+	var api SearchAPI = &MockSearchAPI{
+		SearchFunc: func(request SearchRequest, options SearchOptions) (Itineraries, error) {
+			return nil, errors.New(request.Origin)
+		}}
+	_, err := api.Search(GetTestOneWaySearchRequest(), SearchOptions{})
+	assert.Equal(t, "LGW", err.Error())
+	_, err = api.ListLocales()
+	assert.Equal(t, "Not implemented: ListLocales", err.Error())
+}
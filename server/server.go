@@ -0,0 +1,347 @@
+// Package server exposes a sklib.SearchAPI as JSON over HTTP.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/arthurandres/sklib"
+)
+
+const (
+	defaultTimeout       = 60 * time.Second
+	defaultShutdownGrace = 10 * time.Second
+	readHeaderTimeout    = 10 * time.Second
+	idleTimeout          = 120 * time.Second
+	contentTypeKey       = "Content-Type"
+	jsonContentType      = "application/json"
+)
+
+// ErrTimeout is returned when a call outlasts the request timeout.
+var ErrTimeout = errors.New("Request timed out")
+
+// Server routes:
+//
+//	GET  /browse?country=&currency=&locale=&origin=&departure=&return=
+//	POST /search       {"Request": SearchRequest, "Options": SearchOptions}
+//	GET  /search/stream?country=&currency=&locale=&origin=&destination=&departure=&return=&adults=&direct=
+//	POST /search/stream {"Request": SearchRequest, "Options": SearchOptions}
+//	POST /overview     OverviewRequest
+//	POST /details      DetailsRequest
+//	GET  /locales
+//	GET  /currencies
+//	GET  /countries?locale=
+//	GET  /autosuggest?country=&currency=&locale=&query=
+type Server struct {
+	api     sklib.SearchAPI
+	timeout time.Duration
+	mux     *http.ServeMux
+}
+
+// SearchBody is the body of a search request.
+type SearchBody struct {
+	Request sklib.SearchRequest
+	Options sklib.SearchOptions
+}
+
+// ErrorReply is the body of every error response,
+// the other fields are only set for invalid requests, so clients can rebuild the typed errors:
+// Message for a sklib.ValidationError, Value and Suggestions for a sklib.LocalisationError.
+type ErrorReply struct {
+	Error       string
+	Field       string   `json:",omitempty"`
+	Message     string   `json:",omitempty"`
+	Value       string   `json:",omitempty"`
+	Suggestions []string `json:",omitempty"`
+}
+
+// StatusReply is a sklib.SearchStatus with its error as text.
+type StatusReply struct {
+	Origin      string
+	Destination string
+	Itineraries int
+	Duration    time.Duration
+	Error       string `json:",omitempty"`
+}
+
+type DetailsReply struct {
+	Itineraries sklib.Itineraries
+	Statuses    []StatusReply
+}
+
+// NewServer uses the default timeout of 60 seconds when timeout is 0.
+func NewServer(api sklib.SearchAPI, timeout time.Duration) *Server {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	result := &Server{api: api, timeout: timeout, mux: http.NewServeMux()}
+	result.mux.HandleFunc("/browse", result.get(result.browse))
+	result.mux.HandleFunc("/search", result.post(result.search))
+	result.mux.HandleFunc("/search/stream", result.stream)
+	result.mux.HandleFunc("/overview", result.post(result.overview))
+	result.mux.HandleFunc("/details", result.post(result.details))
+	result.mux.HandleFunc("/locales", result.get(result.locales))
+	result.mux.HandleFunc("/currencies", result.get(result.currencies))
+	result.mux.HandleFunc("/countries", result.get(result.countries))
+	result.mux.HandleFunc("/autosuggest", result.get(result.autosuggest))
+	return result
+}
+
+func (m *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	m.mux.ServeHTTP(writer, request)
+}
+
+// handler returns the reply to encode as JSON.
+type handler func(request *http.Request) (interface{}, error)
+
+func (m *Server) get(call handler) http.HandlerFunc {
+	return m.handle(http.MethodGet, call)
+}
+
+func (m *Server) post(call handler) http.HandlerFunc {
+	return m.handle(http.MethodPost, call)
+}
+
+func (m *Server) handle(method string, call handler) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != method {
+			writer.Header().Set("Allow", method)
+			writeJSON(writer, http.StatusMethodNotAllowed, ErrorReply{Error: fmt.Sprintf("Method %s not allowed", request.Method)})
+			return
+		}
+		reply, err := m.callWithTimeout(request, call)
+		if err != nil {
+			writeError(writer, err)
+			return
+		}
+		writeJSON(writer, http.StatusOK, reply)
+	}
+}
+
+type callResult struct {
+	reply interface{}
+	err   error
+}
+
+// callWithTimeout gives up on the call once the timeout expires or the client goes away.
+// The call carries on in its goroutine until the engine returns, which PollPolicy bounds
+// by giving up on live sessions after MaxRounds polls.
+func (m *Server) callWithTimeout(request *http.Request, call handler) (interface{}, error) {
+	results := make(chan callResult, 1)
+	go func() {
+		defer func() {
+			if value := recover(); value != nil {
+				results <- callResult{nil, recoveredError(value)}
+			}
+		}()
+		reply, err := call(request)
+		results <- callResult{reply, err}
+	}()
+	timer := time.NewTimer(m.timeout)
+	defer timer.Stop()
+	select {
+	case result := <-results:
+		return result.reply, result.err
+	case <-timer.C:
+		return nil, ErrTimeout
+	case <-request.Context().Done():
+		return nil, request.Context().Err()
+	}
+}
+
+// recoveredError turns a panic of the SearchAPI, such as on a malformed upstream reply, into an error,
+// so that it fails the request rather than the server.
+func recoveredError(value interface{}) error {
+	return fmt.Errorf("Search failed: %v", value)
+}
+
+// badRequest reports requests the server could not read.
+type badRequest struct {
+	message string
+}
+
+func (m *badRequest) Error() string {
+	return m.message
+}
+
+func decodeBody(request *http.Request, body interface{}) error {
+	decoder := json.NewDecoder(request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(body); err != nil {
+		return &badRequest{fmt.Sprintf("Invalid JSON body: %s", err)}
+	}
+	return nil
+}
+
+func readLocalisation(request *http.Request) sklib.Localisation {
+	query := request.URL.Query()
+	return sklib.Localisation{
+		Country:  query.Get("country"),
+		Currency: query.Get("currency"),
+		Language: query.Get("locale")}
+}
+
+func (m *Server) browse(request *http.Request) (interface{}, error) {
+	query := request.URL.Query()
+	if len(query.Get("origin")) == 0 {
+		return nil, &sklib.ValidationError{Field: "Origin", Message: "missing"}
+	}
+	if len(query.Get("departure")) == 0 {
+		return nil, &sklib.ValidationError{Field: "DepartureDate", Message: "missing"}
+	}
+	return m.api.Browse(sklib.NewBrowseRouteRequest(
+		readLocalisation(request),
+		query.Get("origin"),
+		query.Get("departure"),
+		query.Get("return")))
+}
+
+func (m *Server) search(request *http.Request) (interface{}, error) {
+	body, err := readSearchBody(request)
+	if err != nil {
+		return nil, err
+	}
+	return m.api.Search(body.Request, body.Options)
+}
+
+func readSearchBody(request *http.Request) (SearchBody, error) {
+	var body SearchBody
+	if err := decodeBody(request, &body); err != nil {
+		return body, err
+	}
+	if len(body.Request.Destinations) == 0 {
+		return body, &sklib.ValidationError{Field: "Destinations", Message: "missing"}
+	}
+	return body, nil
+}
+
+func (m *Server) overview(request *http.Request) (interface{}, error) {
+	var body sklib.OverviewRequest
+	if err := decodeBody(request, &body); err != nil {
+		return nil, err
+	}
+	return m.api.Overview(body)
+}
+
+func (m *Server) details(request *http.Request) (interface{}, error) {
+	var body sklib.DetailsRequest
+	if err := decodeBody(request, &body); err != nil {
+		return nil, err
+	}
+	reply, err := m.api.Details(body)
+	if err != nil {
+		return nil, err
+	}
+	return DetailsReply{reply.Itineraries, readStatuses(reply.Statuses)}, nil
+}
+
+func readStatuses(statuses []sklib.SearchStatus) []StatusReply {
+	results := make([]StatusReply, len(statuses))
+	for index, status := range statuses {
+		results[index] = StatusReply{status.Origin, status.Destination, status.Itineraries, status.Duration, ""}
+		if status.Error != nil {
+			results[index].Error = status.Error.Error()
+		}
+	}
+	return results
+}
+
+func (m *Server) locales(request *http.Request) (interface{}, error) {
+	return m.api.ListLocales()
+}
+
+func (m *Server) currencies(request *http.Request) (interface{}, error) {
+	return m.api.ListCurrencies()
+}
+
+func (m *Server) countries(request *http.Request) (interface{}, error) {
+	return m.api.ListCountries(request.URL.Query().Get("locale"))
+}
+
+func (m *Server) autosuggest(request *http.Request) (interface{}, error) {
+	return m.api.Autosuggest(readLocalisation(request), request.URL.Query().Get("query"))
+}
+
+// ErrorStatus maps errors to HTTP statuses: invalid requests are the client's fault,
+// anything else went wrong upstream.
+func ErrorStatus(err error) int {
+	var validation *sklib.ValidationError
+	var localisation *sklib.LocalisationError
+	var invalid *badRequest
+	switch {
+	case errors.As(err, &validation), errors.As(err, &localisation), errors.As(err, &invalid):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func writeError(writer http.ResponseWriter, err error) {
+	writeJSON(writer, ErrorStatus(err), newErrorReply(err))
+}
+
+func newErrorReply(err error) ErrorReply {
+	reply := ErrorReply{Error: err.Error()}
+	var validation *sklib.ValidationError
+	var localisation *sklib.LocalisationError
+	if errors.As(err, &validation) {
+		reply.Field = validation.Field
+		reply.Message = validation.Message
+	} else if errors.As(err, &localisation) {
+		reply.Field = localisation.Field
+		reply.Value = localisation.Value
+		reply.Suggestions = localisation.Suggestions
+	}
+	return reply
+}
+
+func writeJSON(writer http.ResponseWriter, status int, reply interface{}) {
+	writer.Header().Set(contentTypeKey, jsonContentType)
+	writer.WriteHeader(status)
+	if err := json.NewEncoder(writer).Encode(reply); err != nil {
+		fmt.Println("Failed to write reply:", err)
+	}
+}
+
+// newHTTPServer bounds how long slow clients can hold a connection. There is no read
+// or write timeout, their deadlines would cut the search streams that stay open for the whole search.
+func newHTTPServer(address string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              address,
+		Handler:           handler,
+		ReadHeaderTimeout: readHeaderTimeout,
+		IdleTimeout:       idleTimeout}
+}
+
+// ListenAndServe serves until the context is cancelled, then lets running requests
+// finish for up to grace, 10 seconds when 0.
+func ListenAndServe(ctx context.Context, address string, handler http.Handler, grace time.Duration) error {
+	if grace <= 0 {
+		grace = defaultShutdownGrace
+	}
+	server := newHTTPServer(address, handler)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.ListenAndServe()
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+	shutdown, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+	if err := server.Shutdown(shutdown); err != nil {
+		return err
+	}
+	if err := <-errs; err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
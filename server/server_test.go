@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arthurandres/sklib"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(api sklib.SearchAPI, method string, target string, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	NewServer(api, time.Second).ServeHTTP(recorder, request)
+	return recorder
+}
+
+func serveWithTimeout(api sklib.SearchAPI, timeout time.Duration, method string, target string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	NewServer(api, timeout).ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+	return recorder
+}
+
+func readError(t *testing.T, recorder *httptest.ResponseRecorder) ErrorReply {
+	var reply ErrorReply
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &reply))
+	return reply
+}
+
+func TestBrowse(t *testing.T) {
+	var received sklib.BrowseRoutesRequest
+	api := &sklib.MockSearchAPI{BrowseFunc: func(request sklib.BrowseRoutesRequest) (sklib.FullQuotes, error) {
+		received = request
+		return sklib.FullQuotes{}, nil
+	}}
+	recorder := serve(api, http.MethodGet, "/browse?country=UK&currency=GBP&locale=en-GB&origin=LOND&departure=2016-08-19&return=2016-08-21", "")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "LOND", received.Origin)
+	assert.Equal(t, "2016-08-21", received.ReturnDate)
+	assert.Equal(t, "GBP", received.Localisation.Currency)
+}
+
+func TestBrowseMissingOrigin(t *testing.T) {
+	recorder := serve(&sklib.MockSearchAPI{}, http.MethodGet, "/browse?departure=2016-08-19", "")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, "Origin", readError(t, recorder).Field)
+}
+
+func TestLocalisationError(t *testing.T) {
+	api := &sklib.MockSearchAPI{AutosuggestFunc: func(localisation sklib.Localisation, query string) (sklib.PlaceSuggestions, error) {
+		return nil, &sklib.LocalisationError{Field: "Currency", Value: "GPB", Suggestions: []string{"GBP"}}
+	}}
+	recorder := serve(api, http.MethodGet, "/autosuggest?currency=GPB&query=paris", "")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	reply := readError(t, recorder)
+	assert.Equal(t, "Currency", reply.Field)
+	assert.Equal(t, []string{"GBP"}, reply.Suggestions)
+}
+
+func TestSearch(t *testing.T) {
+	var received sklib.SearchOptions
+	api := &sklib.MockSearchAPI{SearchFunc: func(request sklib.SearchRequest, options sklib.SearchOptions) (sklib.Itineraries, error) {
+		received = options
+		return sklib.Itineraries{}, nil
+	}}
+	body := `{"Request": {"Origin": "LOND", "Destinations": ["PARI"]}, "Options": {"DirectOnly": true, "Limit": 3}}`
+	recorder := serve(api, http.MethodPost, "/search", body)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, received.DirectOnly)
+	assert.Equal(t, 3, received.Limit)
+}
+
+func TestSearchInvalidBody(t *testing.T) {
+	recorder := serve(&sklib.MockSearchAPI{}, http.MethodPost, "/search", "{")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	recorder = serve(&sklib.MockSearchAPI{}, http.MethodPost, "/search", `{"Unknown": 1}`)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	recorder := serve(&sklib.MockSearchAPI{}, http.MethodGet, "/search", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	assert.Equal(t, http.MethodPost, recorder.Header().Get("Allow"))
+}
+
+func TestUpstreamError(t *testing.T) {
+	recorder := serve(&sklib.MockSearchAPI{}, http.MethodGet, "/locales", "")
+	assert.Equal(t, http.StatusBadGateway, recorder.Code)
+	assert.Equal(t, "Not implemented: ListLocales", readError(t, recorder).Error)
+}
+
+func TestPanicRecovered(t *testing.T) {
+	api := &sklib.MockSearchAPI{ListLocalesFunc: func() ([]sklib.LocaleDto, error) {
+		panic("invalid character '<' looking for beginning of value")
+	}}
+	recorder := serve(api, http.MethodGet, "/locales", "")
+	assert.Equal(t, http.StatusBadGateway, recorder.Code)
+	assert.Equal(t, "Search failed: invalid character '<' looking for beginning of value", readError(t, recorder).Error)
+}
+
+// htmlEngine answers like a proxy error page.
+type htmlEngine struct{}
+
+func (m htmlEngine) Get(url string) ([]byte, error) {
+	return []byte("<html>Bad gateway</html>"), nil
+}
+
+func (m htmlEngine) PostAndPoll(url string, form url.Values) ([]byte, error) {
+	return m.Get(url)
+}
+
+func TestMalformedUpstreamReply(t *testing.T) {
+	recorder := serve(sklib.NewEngineSearchAPI(htmlEngine{}), http.MethodGet, "/browse?country=GB&currency=GBP&locale=en-GB&origin=LOND&departure=2016-08-19", "")
+	assert.Equal(t, http.StatusBadGateway, recorder.Code)
+}
+
+func TestTimeout(t *testing.T) {
+	api := &sklib.MockSearchAPI{ListCurrenciesFunc: func() ([]sklib.CurrencyDto, error) {
+		time.Sleep(time.Second)
+		return nil, nil
+	}}
+	recorder := serveWithTimeout(api, 10*time.Millisecond, http.MethodGet, "/currencies")
+	assert.Equal(t, http.StatusGatewayTimeout, recorder.Code)
+}
+
+func TestDetailsStatuses(t *testing.T) {
+	api := &sklib.MockSearchAPI{DetailsFunc: func(request sklib.DetailsRequest) (*sklib.DetailsReply, error) {
+		return &sklib.DetailsReply{Statuses: []sklib.SearchStatus{{Origin: "LOND", Destination: "PARI", Error: errors.New("Failed")}}}, nil
+	}}
+	recorder := serve(api, http.MethodPost, "/details", `{"Origin": "LOND", "Destinations": ["PARI"]}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var reply DetailsReply
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &reply))
+	assert.Equal(t, "Failed", reply.Statuses[0].Error)
+}
+
+func TestListenAndServeShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- ListenAndServe(ctx, "127.0.0.1:0", NewServer(&sklib.MockSearchAPI{}, 0), time.Second)
+	}()
+	cancel()
+	select {
+	case err := <-done:
+		assert.Nil(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Server did not shut down")
+	}
+}
+
+func TestHTTPServerTimeouts(t *testing.T) {
+	server := newHTTPServer("127.0.0.1:0", NewServer(&sklib.MockSearchAPI{}, 0))
+	assert.Equal(t, readHeaderTimeout, server.ReadHeaderTimeout)
+	assert.Equal(t, idleTimeout, server.IdleTimeout)
+	assert.Equal(t, time.Duration(0), server.ReadTimeout)
+	assert.Equal(t, time.Duration(0), server.WriteTimeout)
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arthurandres/sklib"
+)
+
+// Names of the server-sent events.
+const (
+	EventStatus      = "status"
+	EventItineraries = "itineraries"
+	EventDone        = "done"
+	EventError       = "error"
+)
+
+// ErrStreamingUnsupported is returned when the response writer cannot be flushed.
+var ErrStreamingUnsupported = errors.New("Streaming not supported")
+
+// StatusEvent is sent for every poll round of a live session.
+type StatusEvent struct {
+	Origin      string
+	Destination string
+	Status      string
+	Agents      []sklib.AgentStatus
+}
+
+// ItinerariesEvent holds the itineraries a poll round added.
+type ItinerariesEvent struct {
+	Origin      string
+	Destination string
+	Itineraries sklib.Itineraries
+}
+
+// DoneEvent ends a successful stream with the result of the search: the itineraries as priced
+// by the last round, filtered, sorted and truncated following the options.
+type DoneEvent struct {
+	Itineraries sklib.Itineraries
+	Statuses    []StatusReply
+}
+
+type streamResult struct {
+	itineraries sklib.Itineraries
+	statuses    []sklib.SearchStatus
+	err         error
+}
+
+// readSearchQuery reads a search from query parameters, as EventSource only sends GET requests:
+// country, currency, locale, origin, destination (repeated), departure, return, adults and direct.
+func readSearchQuery(request *http.Request) (sklib.SearchRequest, sklib.SearchOptions, error) {
+	query := request.URL.Query()
+	arguments := sklib.SearchRequest{
+		Localisation:  readLocalisation(request),
+		Origin:        query.Get("origin"),
+		Destinations:  query["destination"],
+		DepartureDate: query.Get("departure"),
+		ReturnDate:    query.Get("return")}
+	var options sklib.SearchOptions
+	if len(arguments.Origin) == 0 {
+		return arguments, options, &sklib.ValidationError{Field: "Origin", Message: "missing"}
+	}
+	if len(arguments.Destinations) == 0 {
+		return arguments, options, &sklib.ValidationError{Field: "Destinations", Message: "missing"}
+	}
+	if adults := query.Get("adults"); len(adults) != 0 {
+		value, err := strconv.Atoi(adults)
+		if err != nil {
+			return arguments, options, &sklib.ValidationError{Field: "Adults", Message: adults + " is not a number"}
+		}
+		arguments.Adults = value
+	}
+	if direct := query.Get("direct"); len(direct) != 0 {
+		value, err := strconv.ParseBool(direct)
+		if err != nil {
+			return arguments, options, &sklib.ValidationError{Field: "DirectOnly", Message: direct + " is not a boolean"}
+		}
+		options.DirectOnly = value
+	}
+	return arguments, options, nil
+}
+
+func readStreamRequest(request *http.Request) (sklib.SearchRequest, sklib.SearchOptions, error) {
+	if request.Method == http.MethodPost {
+		body, err := readSearchBody(request)
+		return body.Request, body.Options, err
+	}
+	return readSearchQuery(request)
+}
+
+// stream sends the poll rounds of a live search as server-sent events:
+// a status event per round, an itineraries event when the round added any,
+// then a done event, or an error event if the search failed or timed out.
+// Browsers send the search as query parameters, other clients can post the full request.
+func (m *Server) stream(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet && request.Method != http.MethodPost {
+		writer.Header().Set("Allow", http.MethodGet+", "+http.MethodPost)
+		writeJSON(writer, http.StatusMethodNotAllowed, ErrorReply{Error: fmt.Sprintf("Method %s not allowed", request.Method)})
+		return
+	}
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		writeJSON(writer, http.StatusInternalServerError, ErrorReply{Error: ErrStreamingUnsupported.Error()})
+		return
+	}
+	arguments, options, err := readStreamRequest(request)
+	if err != nil {
+		writeError(writer, err)
+		return
+	}
+
+	// rounds is unbuffered, so every round is received before the result is sent.
+	rounds := make(chan *sklib.LiveProgress)
+	results := make(chan streamResult, 1)
+	finished := make(chan bool)
+	defer close(finished)
+	go func() {
+		defer func() {
+			if value := recover(); value != nil {
+				results <- streamResult{err: recoveredError(value)}
+			}
+		}()
+		itineraries, statuses, err := m.api.SearchProgress(arguments, options, func(progress *sklib.LiveProgress) {
+			select {
+			case rounds <- progress:
+			case <-finished:
+			}
+		})
+		results <- streamResult{itineraries, statuses, err}
+	}()
+
+	writer.Header().Set(contentTypeKey, "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	timer := time.NewTimer(m.timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case progress := <-rounds:
+			writeEvent(writer, EventStatus, StatusEvent{progress.Origin, progress.Destination, progress.Status, progress.Agents})
+			if len(progress.Itineraries) != 0 {
+				writeEvent(writer, EventItineraries, ItinerariesEvent{progress.Origin, progress.Destination, progress.Itineraries})
+			}
+		case result := <-results:
+			if result.err != nil {
+				writeEvent(writer, EventError, newErrorReply(result.err))
+			} else {
+				writeEvent(writer, EventDone, DoneEvent{result.itineraries, readStatuses(result.statuses)})
+			}
+			flusher.Flush()
+			return
+		case <-timer.C:
+			writeEvent(writer, EventError, newErrorReply(ErrTimeout))
+			flusher.Flush()
+			return
+		case <-request.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(writer http.ResponseWriter, name string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		payload, _ = json.Marshal(newErrorReply(err))
+		name = EventError
+	}
+	fmt.Fprintf(writer, "event: %s\ndata: %s\n\n", name, payload)
+}
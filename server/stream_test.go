@@ -0,0 +1,75 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arthurandres/sklib"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	var received sklib.SearchRequest
+	api := &sklib.MockSearchAPI{SearchProgressFunc: func(request sklib.SearchRequest, options sklib.SearchOptions, progress sklib.LiveProgressCallback) (sklib.Itineraries, []sklib.SearchStatus, error) {
+		received = request
+		assert.True(t, options.DirectOnly)
+		agents := []sklib.AgentStatus{{Name: "Agent", Status: sklib.UpdatesPendingStatus}}
+		progress(&sklib.LiveProgress{Origin: "LOND", Destination: "PARI", Status: sklib.UpdatesPendingStatus, Agents: agents})
+		progress(&sklib.LiveProgress{Origin: "LOND", Destination: "PARI", Status: sklib.UpdatesCompleteStatus, Itineraries: sklib.Itineraries{{}}})
+		return sklib.Itineraries{{}}, []sklib.SearchStatus{{Origin: "LOND", Destination: "PARI", Itineraries: 1}}, nil
+	}}
+	recorder := serve(api, http.MethodGet, "/search/stream?origin=LOND&destination=PARI&destination=ROME&departure=20161101&adults=2&direct=true", "")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, []string{"PARI", "ROME"}, received.Destinations)
+	assert.Equal(t, 2, received.Adults)
+
+	events := make([]string, 0)
+	for _, line := range strings.Split(recorder.Body.String(), "\n") {
+		if strings.HasPrefix(line, "event: ") {
+			events = append(events, strings.TrimPrefix(line, "event: "))
+		}
+	}
+	assert.Equal(t, []string{"status", "status", "itineraries", "done"}, events)
+	assert.Contains(t, recorder.Body.String(), `"Status":"UpdatesPending"`)
+	done := recorder.Body.String()[strings.Index(recorder.Body.String(), "event: done\ndata: ")+len("event: done\ndata: "):]
+	var event DoneEvent
+	assert.Nil(t, json.Unmarshal([]byte(done), &event))
+	assert.Equal(t, 1, len(event.Itineraries))
+	assert.Equal(t, 1, event.Statuses[0].Itineraries)
+}
+
+func TestStreamInvalidQuery(t *testing.T) {
+	recorder := serve(&sklib.MockSearchAPI{}, http.MethodGet, "/search/stream?origin=LOND", "")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, "Destinations", readError(t, recorder).Field)
+	recorder = serve(&sklib.MockSearchAPI{}, http.MethodGet, "/search/stream?origin=LOND&destination=PARI&adults=two", "")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestStreamError(t *testing.T) {
+	recorder := serve(&sklib.MockSearchAPI{}, http.MethodGet, "/search/stream?origin=LOND&destination=PARI", "")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "event: error\ndata: {\"Error\":\"Not implemented: SearchProgress\"}")
+}
+
+func TestStreamPanicRecovered(t *testing.T) {
+	api := &sklib.MockSearchAPI{SearchProgressFunc: func(request sklib.SearchRequest, options sklib.SearchOptions, progress sklib.LiveProgressCallback) (sklib.Itineraries, []sklib.SearchStatus, error) {
+		panic("malformed reply")
+	}}
+	recorder := serve(api, http.MethodGet, "/search/stream?origin=LOND&destination=PARI", "")
+	assert.Contains(t, recorder.Body.String(), "event: error\ndata: {\"Error\":\"Search failed: malformed reply\"}")
+}
+
+func TestStreamTimeout(t *testing.T) {
+	api := &sklib.MockSearchAPI{SearchProgressFunc: func(request sklib.SearchRequest, options sklib.SearchOptions, progress sklib.LiveProgressCallback) (sklib.Itineraries, []sklib.SearchStatus, error) {
+		time.Sleep(time.Second)
+		progress(&sklib.LiveProgress{})
+		return nil, nil, nil
+	}}
+	recorder := serveWithTimeout(api, 10*time.Millisecond, http.MethodGet, "/search/stream?origin=LOND&destination=PARI")
+	assert.Contains(t, recorder.Body.String(), "event: error\ndata: {\"Error\":\"Request timed out\"}")
+}
@@ -0,0 +1,122 @@
+package sklib
+
+import "sort"
+
+// OverviewRequest is the first round of a search: a single browse request,
+// returning the quotes the API has cached rather than running live sessions.
+type OverviewRequest struct {
+	Localisation  Localisation
+	Origin        string
+	DepartureDate string
+	ReturnDate    string
+}
+
+// DestinationPrice is the cheapest quote to a destination,
+// DirectPrice is nil when there is no direct quote.
+type DestinationPrice struct {
+	Code        string
+	Name        string
+	Price       Money
+	DirectPrice *Money
+}
+
+type OverviewReply struct {
+	Destinations []*DestinationPrice
+}
+
+// DetailsRequest is the second round: live sessions to the destinations picked from the overview.
+// Airports expands the origin and destinations to the airports of their city.
+type DetailsRequest struct {
+	Localisation  Localisation
+	Origin        string
+	Destinations  []string
+	DepartureDate string
+	ReturnDate    string
+	Airports      bool
+	LiveOptions
+}
+
+// DetailsReply has the status of the live session of every airport pair.
+type DetailsReply struct {
+	Itineraries Itineraries
+	Statuses    []SearchStatus
+}
+
+func (m *EngineSearchAPI) Overview(request OverviewRequest) (*OverviewReply, error) {
+	request.Localisation = m.localise(request.Localisation)
+	return Overview(m.engine, request)
+}
+
+func (m *EngineSearchAPI) Details(request DetailsRequest) (*DetailsReply, error) {
+	request.Localisation = m.localise(request.Localisation)
+	return runDetails(m.engine, request, m.concurrency)
+}
+
+func Overview(engine RequestEngine, request OverviewRequest) (*OverviewReply, error) {
+	quotes, err := Browse(engine, NewBrowseRouteRequest(request.Localisation, request.Origin, request.DepartureDate, request.ReturnDate))
+	if err != nil {
+		return nil, err
+	}
+	return ReadOverview(quotes), nil
+}
+
+// ReadOverview keeps the cheapest and cheapest direct quote of each destination, cheapest first.
+func ReadOverview(quotes FullQuotes) *OverviewReply {
+	byCode := make(map[string]*DestinationPrice)
+	destinations := make([]*DestinationPrice, 0)
+	for _, quote := range quotes {
+		code := quote.Destination.SkyscannerCode
+		price := quote.GetMoney()
+		destination, exists := byCode[code]
+		if !exists {
+			destination = &DestinationPrice{Code: code, Name: quote.Destination.Name, Price: price}
+			byCode[code] = destination
+			destinations = append(destinations, destination)
+		} else if price.Amount < destination.Price.Amount {
+			destination.Price = price
+		}
+		if quote.Quote.Direct && (destination.DirectPrice == nil || price.Amount < destination.DirectPrice.Amount) {
+			directPrice := price
+			destination.DirectPrice = &directPrice
+		}
+	}
+	sort.SliceStable(destinations, func(i, j int) bool {
+		return destinations[i].Price.Amount < destinations[j].Price.Amount
+	})
+	return &OverviewReply{destinations}
+}
+
+func (m *DetailsRequest) SearchRequest() SearchRequest {
+	result := SearchRequest{
+		Localisation:  m.Localisation,
+		Origin:        m.Origin,
+		Destinations:  m.Destinations,
+		DepartureDate: m.DepartureDate,
+		ReturnDate:    m.ReturnDate,
+		LiveOptions:   m.LiveOptions}
+	if m.Airports {
+		result.OriginNearby = NearbyOptions{Mode: NearbyCity}
+		result.DestinationNearby = NearbyOptions{Mode: NearbyCity}
+	}
+	return result
+}
+
+// Details keeps the results of the sessions that succeeded, failures are in the statuses.
+func Details(engine RequestEngine, request DetailsRequest) (*DetailsReply, error) {
+	return runDetails(engine, request, 0)
+}
+
+// runDetails runs the sessions with the given concurrency, 0 for the default.
+func runDetails(engine RequestEngine, request DetailsRequest, concurrency int) (*DetailsReply, error) {
+	if len(request.Destinations) == 0 {
+		return nil, &ValidationError{"Destinations", "missing"}
+	}
+	search := request.SearchRequest()
+	search.Concurrency = concurrency
+	itineraries, statuses, err := SearchWithStatus(engine, search)
+	if err != nil {
+		return nil, err
+	}
+	sort.Stable(itineraries)
+	return &DetailsReply{itineraries, statuses}, nil
+}
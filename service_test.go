@@ -0,0 +1,39 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOverview(t *testing.T) {
+	api := &EngineSearchAPI{engine: &ReplyEngine{ReadOrPanic(WeekendLocation)}}
+	reply, err := api.Overview(OverviewRequest{Localisation{"GB", "GBP", "en-GB"}, "LGW", "20160819", "20160821"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(reply.Destinations))
+	assert.Equal(t, "MAD", reply.Destinations[0].Code)
+	assert.Equal(t, "£49.99", reply.Destinations[0].Price.String())
+	assert.Nil(t, reply.Destinations[0].DirectPrice)
+	assert.Equal(t, "Barcelona", reply.Destinations[1].Name)
+	assert.Equal(t, NewDecimal(64), reply.Destinations[1].DirectPrice.Amount)
+}
+
+func TestDetails(t *testing.T) {
+	api := &EngineSearchAPI{engine: GetTestSearchEngine([]string{"LGW", "LHR"})}
+	request := DetailsRequest{
+		Localisation:  Localisation{"GB", "GBP", "en-GB"},
+		Origin:        "LON",
+		Destinations:  []string{"VIE"},
+		DepartureDate: "20161101",
+		Airports:      true}
+	reply, err := api.Details(request)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(reply.Statuses))
+	assert.Equal(t, 6, len(reply.Itineraries))
+	for index := 1; index < len(reply.Itineraries); index++ {
+		assert.True(t, reply.Itineraries[index-1].GetPrice() <= reply.Itineraries[index].GetPrice())
+	}
+
+	request.Destinations = nil
+	_, err = api.Details(request)
+	assert.Equal(t, "Destinations", err.(*ValidationError).Field)
+}
@@ -4,10 +4,10 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
-	"math"
 	"net/url"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 type LiveRequest struct {
@@ -16,15 +16,39 @@ type LiveRequest struct {
 	Destination   string
 	DepartureDate string
 	ReturnDate    string
+	LiveOptions
 }
 
-// SearchRequest Not a DTO?
+// SearchRequest runs a live search from the origin to each destination.
+// OriginNearby and DestinationNearby expand them to alternate airports.
+// Concurrency caps the live sessions running at once, 0 meaning the default.
 type SearchRequest struct {
-	Localisation  Localisation
-	Origin        string
-	Destinations  []string
-	DepartureDate string
-	ReturnDate    string
+	Localisation      Localisation
+	Origin            string
+	Destinations      []string
+	DepartureDate     string
+	ReturnDate        string
+	OriginNearby      NearbyOptions
+	DestinationNearby NearbyOptions
+	Concurrency       int
+	LiveOptions
+}
+
+// LiveOptions are the optional live session parameters.
+// Zero values fall back to the API defaults: one adult in economy.
+type LiveOptions struct {
+	Adults          int
+	Children        int
+	Infants         int
+	CabinClass      string
+	GroupPricing    bool
+	IncludeCarriers []string
+	ExcludeCarriers []string
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
 }
 
 type Localisation struct {
@@ -119,7 +143,7 @@ type LegDto struct {
 
 type QuoteDto struct {
 	QuoteId       int
-	MinPrice      float64
+	MinPrice      Decimal
 	Direct        bool
 	OutboundLeg   LegDto
 	InboundLeg    LegDto
@@ -204,7 +228,7 @@ type ItineraryLegApiDto struct {
 type PricingOptionApiDto struct {
 	Agents            []int `xml:">int"`
 	QuoteAgeInMinutes int
-	Price             float64
+	Price             Decimal
 	DeeplinkUrl       string
 }
 
@@ -240,6 +264,8 @@ type ItineraryApiDto struct {
 type FullQuote struct {
 	Quote       QuoteDto
 	Destination PlaceDto
+	Currency    *Currency
+	Conversion  *Conversion
 }
 
 type FullQuotes []FullQuote
@@ -271,8 +297,8 @@ func (m *BrowseRoutesReply) GetPlacesByPrice() []string {
 	return results
 }
 
-func (m *BrowseRoutesReply) GetPriceByDestination() map[string]float64 {
-	results := make(map[string]float64)
+func (m *BrowseRoutesReply) GetPriceByDestination() map[string]Decimal {
+	results := make(map[string]Decimal)
 	places := m.GetPlacesById()
 
 	for _, quote := range m.Quotes {
@@ -293,6 +319,23 @@ func (m *BrowseRoutesReply) GetPlacesById() map[int]PlaceDto {
 	return places
 }
 
+// GetCurrency is the currency the quotes are priced in, browse replies carry only that one.
+func (m *BrowseRoutesReply) GetCurrency() *Currency {
+	if len(m.Currencies) == 0 {
+		return nil
+	}
+	currency := Currency(m.Currencies[0])
+	return &currency
+}
+
+func (m *FullQuote) GetMoney() Money {
+	return Money{m.Quote.MinPrice, m.Currency}
+}
+
+func (m *FullQuote) Display() string {
+	return fmt.Sprintf("%s %s", m.Destination.Name, m.GetMoney())
+}
+
 func (m *BrowseRoutesReply) GetCountries() []PlaceDto {
 	results := make([]PlaceDto, 0, len(m.Places))
 	for _, place := range m.Places {
@@ -307,23 +350,30 @@ func (m *BrowseRoutesReply) GetFullQuotes() FullQuotes {
 	results := make(FullQuotes, 0, len(m.Quotes))
 
 	places := m.GetPlacesById()
+	currency := m.GetCurrency()
 	for _, quote := range m.Quotes {
 		if quote.IsReturn() {
 
 			destination := places[quote.OutboundLeg.DestinationId]
-			results = append(results, FullQuote{quote, destination})
+			results = append(results, FullQuote{quote, destination, currency, nil})
 		}
 	}
 	return results
 }
 
 func (m *BrowseRoutesReply) GetBestQuotes() FullQuotes {
+	return m.GetBestQuotesFor(true)
+}
+
+// GetBestQuotesFor keeps the cheapest return or one-way quote per destination.
+func (m *BrowseRoutesReply) GetBestQuotesFor(isReturn bool) FullQuotes {
 	results := make(FullQuotes, 0, len(m.Quotes))
 	mapping := make(map[int]QuoteDto)
 
 	places := m.GetPlacesById()
+	currency := m.GetCurrency()
 	for _, quote := range m.Quotes {
-		if quote.IsReturn() {
+		if quote.IsTrip(isReturn) {
 			bestQuote, exists := mapping[quote.OutboundLeg.DestinationId]
 			if !exists || quote.MinPrice < bestQuote.MinPrice {
 				mapping[quote.OutboundLeg.DestinationId] = quote
@@ -333,13 +383,13 @@ func (m *BrowseRoutesReply) GetBestQuotes() FullQuotes {
 
 	for _, quote := range mapping {
 		destination := places[quote.OutboundLeg.DestinationId]
-		results = append(results, FullQuote{quote, destination})
+		results = append(results, FullQuote{quote, destination, currency, nil})
 	}
 	return results
 }
 
-func (m *BrowseRoutesReply) GetBestPrice() float64 {
-	result := math.MaxFloat64
+func (m *BrowseRoutesReply) GetBestPrice() Decimal {
+	result := MaxDecimal
 	for _, quote := range m.Quotes {
 		if quote.IsReturn() && quote.MinPrice < result {
 			result = quote.MinPrice
@@ -372,16 +422,13 @@ func (slice Routes) Swap(i, j int) {
 	slice[i], slice[j] = slice[j], slice[i]
 }
 
-func (m *RouteDto) GetPrice() int {
-	price, err := strconv.Atoi(string(m.Price))
-	if err != nil {
-		panic(err)
-	}
-	return price
+func (m *RouteDto) GetPrice() Decimal {
+	return ParseDecimalOP(string(m.Price))
 }
 
+// Valid is false for routes without a price, fractional prices are valid.
 func (m *RouteDto) Valid() bool {
-	_, err := strconv.Atoi(string(m.Price))
+	_, err := ParseDecimal(string(m.Price))
 	return err == nil
 }
 
@@ -394,6 +441,18 @@ func (m *QuoteDto) IsReturn() bool {
 		m.InboundLeg.DestinationId != 0
 }
 
+func (m *QuoteDto) IsOneWay() bool {
+	return m.OutboundLeg.DestinationId != 0 &&
+		m.InboundLeg.DestinationId == 0
+}
+
+func (m *QuoteDto) IsTrip(isReturn bool) bool {
+	if isReturn {
+		return m.IsReturn()
+	}
+	return m.IsOneWay()
+}
+
 func (slice FullQuotes) Len() int {
 	return len(slice)
 }
@@ -441,25 +500,116 @@ func NewBrowseRouteRequest(local Localisation, origin string, departureDate stri
 }
 
 func (m BrowseRoutesRequest) Url() string {
-	return fmt.Sprintf(
-		browseRouteFormat,
+	return m.ServiceUrl(browseRoutesPath)
+}
+
+// ServiceUrl builds the url of any browse service, they all share the same parameters.
+func (m BrowseRoutesRequest) ServiceUrl(service string) string {
+	result := fmt.Sprintf(
+		browseFormat,
+		service,
 		m.Localisation.SubURL(),
 		m.Origin,
 		m.Destination,
-		m.DepartureDate,
-		m.ReturnDate)
+		m.DepartureDate)
+	if m.IsReturn() {
+		result += "/" + m.ReturnDate
+	}
+	return result
+}
+
+func (m *SearchRequest) GetConcurrency() int {
+	if m.Concurrency == 0 {
+		return defaultSearchConcurrency
+	}
+	return m.Concurrency
+}
+
+func (m BrowseRoutesRequest) IsReturn() bool {
+	return len(m.ReturnDate) != 0
 }
 
 func (m *LiveRequest) Values() url.Values {
-	return url.Values{
+	values := url.Values{
 		"country":          {m.Localisation.Country},
 		"currency":         {m.Localisation.Currency},
 		"locale":           {m.Localisation.Language},
 		"originplace":      {m.Origin},
 		"destinationplace": {m.Destination},
 		"outbounddate":     {FormatDateToForm(m.DepartureDate)},
-		"inbounddate":      {FormatDateToForm(m.ReturnDate)},
-		"locationschema":   {"Iata"}}
+		"locationschema":   {"Iata"},
+		"adults":           {strconv.Itoa(m.GetAdults())},
+		"children":         {strconv.Itoa(m.Children)},
+		"infants":          {strconv.Itoa(m.Infants)},
+		"cabinclass":       {m.GetCabinClass()},
+		"groupPricing":     {strconv.FormatBool(m.GroupPricing)}}
+	if m.IsReturn() {
+		values.Set("inbounddate", FormatDateToForm(m.ReturnDate))
+	}
+	if len(m.IncludeCarriers) > 0 {
+		values.Set("includeCarriers", strings.Join(m.IncludeCarriers, ";"))
+	}
+	if len(m.ExcludeCarriers) > 0 {
+		values.Set("excludeCarriers", strings.Join(m.ExcludeCarriers, ";"))
+	}
+	return values
+}
+
+func (m *LiveRequest) Validate() error {
+	if len(m.Origin) == 0 {
+		return &ValidationError{"Origin", "missing"}
+	}
+	if len(m.Destination) == 0 {
+		return &ValidationError{"Destination", "missing"}
+	}
+	departure, err := ParseUrlDate(m.DepartureDate)
+	if err != nil {
+		return &ValidationError{"DepartureDate", err.Error()}
+	}
+	if m.IsReturn() {
+		inbound, err := ParseUrlDate(m.ReturnDate)
+		if err != nil {
+			return &ValidationError{"ReturnDate", err.Error()}
+		}
+		if inbound.Before(departure) {
+			return &ValidationError{"ReturnDate", "before departure date"}
+		}
+	}
+	return m.LiveOptions.Validate()
+}
+
+// IsReturn is false for one-way requests, which have no ReturnDate.
+func (m *LiveRequest) IsReturn() bool {
+	return len(m.ReturnDate) != 0
+}
+
+// CheckQuery makes sure the query echoed by a live reply is the one that was sent.
+func (m *LiveRequest) CheckQuery(query *LiveQueryDto) error {
+	checks := []struct {
+		field     string
+		requested interface{}
+		received  interface{}
+	}{
+		{"Country", strings.ToUpper(m.Localisation.Country), strings.ToUpper(query.Country)},
+		{"Currency", strings.ToUpper(m.Localisation.Currency), strings.ToUpper(query.Currency)},
+		{"Locale", strings.ToLower(m.Localisation.Language), strings.ToLower(query.Locale)},
+		{"OutboundDate", FormatDateToForm(m.DepartureDate), query.OutboundDate},
+		{"InboundDate", formatOptionalDateToForm(m.ReturnDate), query.InboundDate},
+		{"Adults", m.GetAdults(), query.Adults},
+		{"Children", m.Children, query.Children},
+		{"Infants", m.Infants, query.Infants},
+		{"CabinClass", m.GetCabinClass(), query.CabinClass},
+		{"GroupPricing", m.GroupPricing, query.GroupPricing},
+	}
+	for _, check := range checks {
+		if check.requested != check.received {
+			return fmt.Errorf("Query mismatch on %s: requested %v, received %v",
+				check.field,
+				check.requested,
+				check.received)
+		}
+	}
+	return nil
 }
 
 func (m *LiveRequest) Encode() string {
@@ -471,14 +621,60 @@ func NewLiveRequest(
 	origin string,
 	destination string,
 	departureDate string,
-	returnDate string) LiveRequest {
+	returnDate string,
+	options LiveOptions) LiveRequest {
 
 	return LiveRequest{
 		localisation,
 		origin,
 		destination,
 		departureDate,
-		returnDate}
+		returnDate,
+		options}
+}
+
+func (m *LiveOptions) GetAdults() int {
+	if m.Adults == 0 {
+		return 1
+	}
+	return m.Adults
+}
+
+func (m *LiveOptions) GetCabinClass() string {
+	if len(m.CabinClass) == 0 {
+		return EconomyCabin
+	}
+	return m.CabinClass
+}
+
+func (m *LiveOptions) Validate() error {
+	adults := m.GetAdults()
+	if adults < 1 || adults > maxPassengers {
+		return &ValidationError{"Adults", fmt.Sprintf("%d not in [1, %d]", m.Adults, maxPassengers)}
+	}
+	if m.Children < 0 || m.Children > maxPassengers {
+		return &ValidationError{"Children", fmt.Sprintf("%d not in [0, %d]", m.Children, maxPassengers)}
+	}
+	if m.Infants < 0 || m.Infants > adults {
+		return &ValidationError{"Infants", fmt.Sprintf("%d not in [0, %d]", m.Infants, adults)}
+	}
+	switch m.GetCabinClass() {
+	case EconomyCabin, PremiumEconomyCabin, BusinessCabin, FirstCabin:
+	default:
+		return &ValidationError{"CabinClass", fmt.Sprintf("unknown cabin class %s", m.CabinClass)}
+	}
+	for _, included := range m.IncludeCarriers {
+		for _, excluded := range m.ExcludeCarriers {
+			if included == excluded {
+				return &ValidationError{"ExcludeCarriers", fmt.Sprintf("%s is also included", excluded)}
+			}
+		}
+	}
+	return nil
+}
+
+func (m *ValidationError) Error() string {
+	return fmt.Sprintf("Invalid %s: %s", m.Field, m.Message)
 }
 
 func (m FullQuotes) GetTowns() []PlaceDto {
@@ -0,0 +1,100 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func GetTestLiveRequest() LiveRequest {
+	return NewLiveRequest(
+		Localisation{"GB", "GBP", "en-GB"},
+		"LON",
+		"VIE",
+		"20161101",
+		"20161103",
+		LiveOptions{})
+}
+
+func TestLiveRequestValues(t *testing.T) {
+	request := GetTestLiveRequest()
+	request.Children = 2
+	request.CabinClass = BusinessCabin
+	request.IncludeCarriers = []string{"EZY", "BA"}
+
+	values := request.Values()
+	assert.Equal(t, "1", values.Get("adults"))
+	assert.Equal(t, "2", values.Get("children"))
+	assert.Equal(t, "0", values.Get("infants"))
+	assert.Equal(t, "Business", values.Get("cabinclass"))
+	assert.Equal(t, "false", values.Get("groupPricing"))
+	assert.Equal(t, "EZY;BA", values.Get("includeCarriers"))
+	assert.Equal(t, "", values.Get("excludeCarriers"))
+	assert.Equal(t, "2016-11-03", values.Get("inbounddate"))
+}
+
+func TestLiveRequestValidate(t *testing.T) {
+	request := GetTestLiveRequest()
+	assert.Nil(t, request.Validate())
+
+	request.Infants = 2
+	err := request.Validate()
+	assert.Equal(t, "Infants", err.(*ValidationError).Field)
+
+	request = GetTestLiveRequest()
+	request.CabinClass = "Cargo"
+	assert.Equal(t, "CabinClass", request.Validate().(*ValidationError).Field)
+
+	request = GetTestLiveRequest()
+	request.Adults = 9
+	assert.Equal(t, "Adults", request.Validate().(*ValidationError).Field)
+
+	request = GetTestLiveRequest()
+	request.IncludeCarriers = []string{"EZY"}
+	request.ExcludeCarriers = []string{"EZY"}
+	assert.Equal(t, "ExcludeCarriers", request.Validate().(*ValidationError).Field)
+
+	request = GetTestLiveRequest()
+	request.DepartureDate = "2016-11-01"
+	assert.Equal(t, "DepartureDate", request.Validate().(*ValidationError).Field)
+}
+
+func TestLiveRequestCheckQuery(t *testing.T) {
+	reply := GetTestLiveReply()
+	request := GetTestLiveRequest()
+	assert.Nil(t, request.CheckQuery(&reply.Query))
+
+	request.Adults = 2
+	assert.NotNil(t, request.CheckQuery(&reply.Query))
+
+	request = GetTestLiveRequest()
+	request.ReturnDate = "20161104"
+	assert.NotNil(t, request.CheckQuery(&reply.Query))
+}
+
+func TestOneWayLiveRequest(t *testing.T) {
+	request := GetTestLiveRequest()
+	request.ReturnDate = ""
+	assert.Nil(t, request.Validate())
+	_, exists := request.Values()["inbounddate"]
+	assert.False(t, exists)
+
+	var reply LiveReply
+	ParseFromJsonFile(LiveOneWayJsonLocation, &reply)
+	assert.Nil(t, request.CheckQuery(&reply.Query))
+
+	request.ReturnDate = "20161031"
+	assert.Equal(t, "ReturnDate", request.Validate().(*ValidationError).Field)
+}
+
+func TestOneWayBrowseRoutesRequest(t *testing.T) {
+	request := NewBrowseRouteRequest(Localisation{"GB", "GBP", "en-GB"}, "LON", "20160819", "")
+	assert.Equal(t, "http://partners.api.skyscanner.net/apiservices/browseroutes/v1.0/GB/GBP/en-GB/LON/anywhere/20160819", request.Url())
+	request.ReturnDate = "20160821"
+	assert.Equal(t, browseRouteExample, request.Url())
+}
+
+func TestGetBestQuotesFor(t *testing.T) {
+	reply := ParseBrowseRoutesReplyJson(ReadOrPanic(AnywhereLocationJson))
+	assert.Equal(t, len(reply.GetBestQuotes()), len(reply.GetBestQuotesFor(true)))
+	assert.Equal(t, 0, len(reply.GetBestQuotesFor(false)))
+}
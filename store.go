@@ -1,10 +1,19 @@
 package sklib
 
-import "github.com/boltdb/bolt"
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
 
 const (
-	cacheLocation = "cache.db"
-	bucketName    = "cache"
+	cacheLocation      = "cache.db"
+	bucketName         = "cache"
+	expiringBucketName = "cache-expiring"
+	expiringHeaderSize = 8
 )
 
 type CacheStore interface {
@@ -21,6 +30,18 @@ type WriteOnlyStore struct {
 	Store CacheStore
 }
 
+type MemoryStore struct {
+	data map[string][]byte
+	lock sync.RWMutex
+}
+
+// ExpiringStore prefixes entries with their creation time and ignores them once older than TTL.
+type ExpiringStore struct {
+	Store CacheStore
+	TTL   time.Duration
+	Now   func() time.Time
+}
+
 func (m *BoltStore) Get(key string) []byte {
 	var value []byte
 	m.DB.View(func(tx *bolt.Tx) error {
@@ -48,6 +69,28 @@ func (m *BoltStore) Set(key string, data []byte) error {
 	return err
 }
 
+// Count is the number of cached entries.
+func (m *BoltStore) Count() (int, error) {
+	count := 0
+	err := m.DB.View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket([]byte(m.Bucket)); b != nil {
+			count = b.Stats().KeyN
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Clear drops every cached entry.
+func (m *BoltStore) Clear() error {
+	return m.DB.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket([]byte(m.Bucket)); err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+		return nil
+	})
+}
+
 func (m *WriteOnlyStore) Get(key string) []byte {
 	return nil
 }
@@ -56,12 +99,62 @@ func (m *WriteOnlyStore) Set(key string, data []byte) error {
 	return m.Store.Set(key, data)
 }
 
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{data: make(map[string][]byte)}
+}
+
+func (m *MemoryStore) Get(key string) []byte {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.data[key]
+}
+
+func (m *MemoryStore) Set(key string, data []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.data[key] = data
+	return nil
+}
+
+func (m *ExpiringStore) Get(key string) []byte {
+	data := m.Store.Get(key)
+	if len(data) < expiringHeaderSize {
+		return nil
+	}
+	created := time.Unix(0, int64(binary.BigEndian.Uint64(data)))
+	if m.now().Sub(created) > m.TTL {
+		return nil
+	}
+	return data[expiringHeaderSize:]
+}
+
+func (m *ExpiringStore) Set(key string, data []byte) error {
+	entry := make([]byte, expiringHeaderSize+len(data))
+	binary.BigEndian.PutUint64(entry, uint64(m.now().UnixNano()))
+	copy(entry[expiringHeaderSize:], data)
+	return m.Store.Set(key, entry)
+}
+
+func (m *ExpiringStore) now() time.Time {
+	if m.Now == nil {
+		return time.Now()
+	}
+	return m.Now()
+}
+
 func CreateDB() *bolt.DB {
-	var db *bolt.DB
-	var err error
-	db, err = bolt.Open(cacheLocation, 0600, nil)
+	db, err := OpenDB(cacheLocation)
 	if err != nil {
 		panic(err)
 	}
 	return db
 }
+
+// OpenDB gives up after a second when another process holds the database.
+func OpenDB(path string) (*bolt.DB, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("Could not open cache %s: %s", path, err)
+	}
+	return db, nil
+}
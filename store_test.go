@@ -0,0 +1,63 @@
+package sklib
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExpiringStore(t *testing.T) {
+	now := time.Date(2016, 8, 19, 12, 0, 0, 0, time.UTC)
+	store := &ExpiringStore{
+		Store: NewMemoryStore(),
+		TTL:   time.Hour,
+		Now:   func() time.Time { return now }}
+
+	assert.Nil(t, store.Get("key"))
+	assert.Nil(t, store.Set("key", []byte("value")))
+	assert.Equal(t, "value", string(store.Get("key")))
+
+	now = now.Add(59 * time.Minute)
+	assert.Equal(t, "value", string(store.Get("key")))
+	now = now.Add(2 * time.Minute)
+	assert.Nil(t, store.Get("key"))
+}
+
+func TestBoltStoreCountAndClear(t *testing.T) {
+	directory, err := ioutil.TempDir("", "sklib")
+	assert.Nil(t, err)
+	defer os.RemoveAll(directory)
+	db, err := bolt.Open(filepath.Join(directory, cacheLocation), 0600, nil)
+	assert.Nil(t, err)
+	defer db.Close()
+	store := CreateCache(db)
+
+	count, err := store.Count()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+	assert.Nil(t, store.Clear())
+
+	assert.Nil(t, store.Set("first", []byte("value")))
+	assert.Nil(t, store.Set("second", []byte("value")))
+	count, err = store.Count()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	assert.Nil(t, store.Clear())
+	count, err = store.Count()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+	assert.Nil(t, store.Get("first"))
+
+	expiring := CreateExpiringCache(db, time.Hour)
+	assert.Nil(t, expiring.Set("first", []byte("value")))
+	buckets := CreateCacheBuckets(db)
+	assert.Equal(t, 2, len(buckets))
+	count, err = buckets[1].Count()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+}
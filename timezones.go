@@ -0,0 +1,45 @@
+package sklib
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	locations     = make(map[string]*time.Location)
+	locationsLock sync.Mutex
+)
+
+// FindTimeZone looks the IANA time zone of an airport or city up in the default reference dataset.
+func FindTimeZone(code string) (string, bool) {
+	place, exists := DefaultReference().Find(code)
+	if !exists || len(place.TimeZone) == 0 {
+		return "", false
+	}
+	return place.TimeZone, true
+}
+
+// FindLocation returns nil, without error, for codes missing from the dataset.
+func FindLocation(code string) (*time.Location, error) {
+	zone, exists := FindTimeZone(code)
+	if !exists {
+		return nil, nil
+	}
+	return LoadTimeZone(zone)
+}
+
+// LoadTimeZone caches time.LoadLocation, which reads the zone file on every call.
+// Hosts without zoneinfo need the time/tzdata package, or the timetzdata build tag.
+func LoadTimeZone(zone string) (*time.Location, error) {
+	locationsLock.Lock()
+	defer locationsLock.Unlock()
+	if location, exists := locations[zone]; exists {
+		return location, nil
+	}
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, err
+	}
+	locations[zone] = location
+	return location, nil
+}
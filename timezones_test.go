@@ -0,0 +1,27 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFindLocation(t *testing.T) {
+	location, err := FindLocation("VIE")
+	assert.Nil(t, err)
+	assert.Equal(t, "Europe/Vienna", location.String())
+
+	again, _ := FindLocation("VIE")
+	assert.True(t, location == again)
+
+	location, err = FindLocation("XXX")
+	assert.Nil(t, err)
+	assert.Nil(t, location)
+}
+
+func TestTimeZonesLoad(t *testing.T) {
+	for _, place := range DefaultReference().GetPlaces() {
+		location, err := LoadTimeZone(place.TimeZone)
+		assert.Nil(t, err, place.Code)
+		assert.Equal(t, place.TimeZone, location.String())
+	}
+}
@@ -2,18 +2,27 @@ package sklib
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
+	"os"
 	"strings"
 )
 
+// Progress receives the messages searches print as they go, stdout by default.
+var Progress io.Writer = os.Stdout
+
 func insertAll(from map[string]float64, to map[string]float64) {
 	for k, v := range from {
 		to[k] = v
 	}
 }
 
-func formatKey(key string) string {
-	return apiKeyTag + key
+// formatKey appends the key as the first or as an additional query parameter.
+func formatKey(url string, key string) string {
+	if strings.Contains(url, "?") {
+		return url + "&" + apiKeyParameter + key
+	}
+	return url + "?" + apiKeyParameter + key
 }
 
 func ReadFromFile(fileName string) (string, error) {
@@ -25,9 +34,61 @@ func ReadFromFile(fileName string) (string, error) {
 }
 
 func ReadKey(fileName string) string {
+	key, err := LoadKey(fileName)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func LoadKey(fileName string) (string, error) {
 	data, err := ReadFromFile(fileName)
 	if err != nil {
-		panic(fmt.Errorf("Could not read key from %s", fileName))
+		return "", fmt.Errorf("Could not read key from %s", fileName)
+	}
+	key := strings.TrimSpace(data)
+	if len(key) == 0 {
+		return "", fmt.Errorf("Empty key in %s", fileName)
+	}
+	return key, nil
+}
+
+// editDistance is the Damerau-Levenshtein distance, counting a swap of two adjacent letters as one edit.
+func editDistance(left, right string) int {
+	a := []rune(left)
+	b := []rune(right)
+	distances := make([][]int, len(a)+1)
+	for i := range distances {
+		distances[i] = make([]int, len(b)+1)
+		distances[i][0] = i
+	}
+	for j := range distances[0] {
+		distances[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			distances[i][j] = minInt(
+				distances[i-1][j]+1,
+				distances[i][j-1]+1,
+				distances[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				distances[i][j] = minInt(distances[i][j], distances[i-2][j-2]+1)
+			}
+		}
+	}
+	return distances[len(a)][len(b)]
+}
+
+func minInt(first int, others ...int) int {
+	result := first
+	for _, value := range others {
+		if value < result {
+			result = value
+		}
 	}
-	return strings.TrimSpace(data)
+	return result
 }
@@ -0,0 +1,148 @@
+package sklib
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	defaultWeekends = 4
+	daysPerWeek     = 7
+)
+
+// WeekendRequest looks for the cheapest getaway of the next Weekends weekends.
+// DepartureDays default to Friday and ReturnDays to Sunday,
+// departures are counted back from Saturday and returns forward from it.
+type WeekendRequest struct {
+	Localisation  Localisation
+	Origin        string
+	Weekends      int
+	DepartureDays []time.Weekday
+	ReturnDays    []time.Weekday
+	DirectOnly    bool
+	Today         time.Time
+}
+
+type Weekend struct {
+	Departure time.Time
+	Return    time.Time
+}
+
+// WeekendResult is the cheapest weekend found for a destination.
+type WeekendResult struct {
+	Destination PlaceDto
+	Weekend     Weekend
+	Quote       FullQuote
+}
+
+type WeekendResults []*WeekendResult
+
+func (m *WeekendRequest) GetWeekends() int {
+	if m.Weekends <= 0 {
+		return defaultWeekends
+	}
+	return m.Weekends
+}
+
+func (m *WeekendRequest) GetDepartureDays() []time.Weekday {
+	if len(m.DepartureDays) == 0 {
+		return []time.Weekday{time.Friday}
+	}
+	return m.DepartureDays
+}
+
+func (m *WeekendRequest) GetReturnDays() []time.Weekday {
+	if len(m.ReturnDays) == 0 {
+		return []time.Weekday{time.Sunday}
+	}
+	return m.ReturnDays
+}
+
+func (m *WeekendRequest) GetToday() time.Time {
+	if m.Today.IsZero() {
+		return time.Now()
+	}
+	return m.Today
+}
+
+// NextWeekends lists every departure and return day combination of the coming weekends,
+// skipping departures before today.
+func (m *WeekendRequest) NextWeekends() []Weekend {
+	today := truncateDay(m.GetToday())
+	saturday := today.AddDate(0, 0, (int(time.Saturday)-int(today.Weekday())+daysPerWeek)%daysPerWeek)
+	if saturday.Equal(today) {
+		saturday = saturday.AddDate(0, 0, daysPerWeek)
+	}
+	results := make([]Weekend, 0)
+	for week := 0; week < m.GetWeekends(); week++ {
+		for _, departureDay := range m.GetDepartureDays() {
+			departure := saturday.AddDate(0, 0, -((int(time.Saturday) - int(departureDay)) % daysPerWeek))
+			if departure.Before(today) {
+				continue
+			}
+			for _, returnDay := range m.GetReturnDays() {
+				inbound := saturday.AddDate(0, 0, (int(returnDay)-int(time.Saturday)+daysPerWeek)%daysPerWeek)
+				results = append(results, Weekend{departure, inbound})
+			}
+		}
+		saturday = saturday.AddDate(0, 0, daysPerWeek)
+	}
+	return results
+}
+
+func (m Weekend) BrowseRequest(request *WeekendRequest) BrowseRoutesRequest {
+	return NewBrowseRouteRequest(
+		request.Localisation,
+		request.Origin,
+		m.Departure.Format(DateFormatUrl),
+		m.Return.Format(DateFormatUrl))
+}
+
+// FindWeekends runs an anywhere Browse per weekend,
+// and keeps the cheapest weekend of each destination, cheapest destination first.
+func FindWeekends(engine RequestEngine, request WeekendRequest) (WeekendResults, error) {
+	if len(request.Origin) == 0 {
+		return nil, &ValidationError{"Origin", "missing"}
+	}
+	best := make(map[string]*WeekendResult)
+	for _, weekend := range request.NextWeekends() {
+		fmt.Fprintln(Progress, "Searching weekend", weekend.Departure.Format(DateFormatForm), weekend.Return.Format(DateFormatForm))
+		quotes, err := Browse(engine, weekend.BrowseRequest(&request))
+		if err != nil {
+			return nil, err
+		}
+		if request.DirectOnly {
+			quotes = quotes.FilterDirects()
+		}
+		for _, quote := range quotes {
+			code := quote.Destination.SkyscannerCode
+			if current, exists := best[code]; !exists || quote.Quote.MinPrice < current.Quote.Quote.MinPrice {
+				best[code] = &WeekendResult{quote.Destination, weekend, quote}
+			}
+		}
+	}
+	results := make(WeekendResults, 0, len(best))
+	for _, result := range best {
+		results = append(results, result)
+	}
+	sort.Sort(results)
+	return results, nil
+}
+
+func (slice WeekendResults) Len() int {
+	return len(slice)
+}
+
+func (slice WeekendResults) Less(i, j int) bool {
+	left := slice[i].Quote.Quote.MinPrice
+	right := slice[j].Quote.Quote.MinPrice
+	if left == right {
+		return slice[i].Destination.SkyscannerCode < slice[j].Destination.SkyscannerCode
+	}
+	return left < right
+}
+
+func (slice WeekendResults) Swap(i, j int) {
+	slice[i], slice[j] = slice[j], slice[i]
+}
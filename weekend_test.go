@@ -0,0 +1,54 @@
+package sklib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNextWeekends(t *testing.T) {
+	// A Friday: this weekend's Friday departure is still possible.
+	request := WeekendRequest{Weekends: 2, Today: time.Date(2016, 8, 19, 10, 0, 0, 0, time.UTC)}
+	weekends := request.NextWeekends()
+	assert.Equal(t, []Weekend{
+		{utcDay(2016, 8, 19), utcDay(2016, 8, 21)},
+		{utcDay(2016, 8, 26), utcDay(2016, 8, 28)}}, weekends)
+
+	request.DepartureDays = []time.Weekday{time.Thursday, time.Friday}
+	request.ReturnDays = []time.Weekday{time.Sunday, time.Monday}
+	weekends = request.NextWeekends()
+	assert.Equal(t, 6, len(weekends))
+	assert.Equal(t, Weekend{utcDay(2016, 8, 19), utcDay(2016, 8, 22)}, weekends[1])
+	assert.Equal(t, Weekend{utcDay(2016, 8, 25), utcDay(2016, 8, 28)}, weekends[2])
+
+	// On a Saturday, the coming weekend is the next one.
+	request = WeekendRequest{Weekends: 1, Today: utcDay(2016, 8, 20)}
+	assert.Equal(t, []Weekend{{utcDay(2016, 8, 26), utcDay(2016, 8, 28)}}, request.NextWeekends())
+}
+
+const WeekendLocation = TestDataBase + "weekend.json"
+
+func TestFindWeekends(t *testing.T) {
+	engine := &ReplyEngine{ReadOrPanic(WeekendLocation)}
+	request := WeekendRequest{
+		Localisation: Localisation{"GB", "GBP", "en-GB"},
+		Origin:       "LON",
+		Weekends:     2,
+		Today:        utcDay(2016, 8, 15)}
+	results, err := FindWeekends(engine, request)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(results))
+	assert.Equal(t, "MAD", results[0].Destination.SkyscannerCode)
+	assert.Equal(t, "BCN", results[1].Destination.SkyscannerCode)
+	// Both weekends get the same quotes, ties keep the first weekend.
+	assert.Equal(t, utcDay(2016, 8, 19), results[0].Weekend.Departure)
+
+	request.DirectOnly = true
+	directs, err := FindWeekends(engine, request)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(directs))
+	assert.Equal(t, "BCN", directs[0].Destination.SkyscannerCode)
+
+	_, err = FindWeekends(engine, WeekendRequest{Localisation: request.Localisation})
+	assert.Equal(t, "Origin", err.(*ValidationError).Field)
+}